@@ -0,0 +1,462 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Sisu/db"
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var calCmd = &cobra.Command{
+	Use:               "cal [YYYY-MM]",
+	Short:             "Month calendar shaded by session minutes",
+	Long:              helpCal,
+	Example:           exampleCal,
+	Args:              cobra.MaximumNArgs(1),
+	PersistentPreRun:  dbPreRun,
+	PersistentPostRun: dbPostRun,
+	Run:               runCal,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	flagCalTask        string
+	flagCalTag         string
+	flagCalInteractive bool
+)
+
+// Day marks, highest priority first.
+const (
+	calMarkTarget    = "◎"
+	calMarkMilestone = "★"
+	calMarkNote      = "•"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(calCmd)
+
+	calCmd.Flags().StringVar(&flagCalTask, "task", "", "only show this task (ID or name)")
+	calCmd.Flags().StringVar(&flagCalTag, "tag", "", "only show tasks with this tag")
+	calCmd.Flags().BoolVarP(&flagCalInteractive, "interactive", "i", false, "browse months and days in a TUI")
+
+	_ = calCmd.RegisterFlagCompletionFunc("task", completeTaskArg)
+	_ = calCmd.RegisterFlagCompletionFunc("tag", completeTagNames)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runCal(_ *cobra.Command, args []string) {
+	ctx := db.Ctx()
+
+	first := today()
+	if len(args) == 1 {
+		m, err := time.Parse("2006-01", args[0])
+		if err != nil {
+			log.Fatalf("invalid month %q (use YYYY-MM)", args[0])
+		}
+		first = m
+	}
+	first = time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	f := sessionFilter{Tag: flagCalTag}
+	if flagCalTask != "" {
+		task, err := resolveTask(ctx, db.Conn, flagCalTask)
+		if err != nil {
+			log.Fatalf("resolve task: %v", err)
+		}
+		f.Task = task.ID.Int64
+	}
+
+	month, err := loadCalMonth(ctx, db.Conn, first, f)
+	if err != nil {
+		log.Fatalf("load month: %v", err)
+	}
+	weekStart := settingWeekday(settingWeekStart)
+
+	if !flagCalInteractive {
+		fmt.Print(renderCalMonth(month, weekStart, 0, colorEnabled()))
+		fmt.Print(renderCalEvents(month))
+		return
+	}
+
+	sel := first
+	if t := today(); t.Year() == first.Year() && t.Month() == first.Month() {
+		sel = t
+	}
+	m := &calModel{filter: f, weekStart: weekStart, color: colorEnabled(), month: month, sel: sel}
+	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+		log.Fatalf("calendar: %v", err)
+	}
+	if m.err != nil {
+		log.Fatalf("calendar: %v", m.err)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// calMonth gathers everything drawn for one month, keyed by day of month.
+type calMonth struct {
+	First      time.Time
+	Mins       map[int]int64
+	Notes      map[int][]*models.Calendar
+	Milestones map[int][]string
+	Targets    map[int][]string
+	Names      map[int64]string // task names in scope
+}
+
+func (m *calMonth) days() int {
+	return m.First.AddDate(0, 1, -1).Day()
+}
+
+func (m *calMonth) last() time.Time {
+	return m.First.AddDate(0, 1, -1)
+}
+
+// loadCalMonth reads minutes per day, calendar notes, milestone done dates and task targets.
+func loadCalMonth(ctx context.Context, exec boil.ContextExecutor, first time.Time, f sessionFilter) (*calMonth, error) {
+	m := &calMonth{
+		First:      first,
+		Mins:       map[int]int64{},
+		Notes:      map[int][]*models.Calendar{},
+		Milestones: map[int][]string{},
+		Targets:    map[int][]string{},
+		Names:      map[int64]string{},
+	}
+	from, to := first.Format(DateYMD), m.last().Format(DateYMD)
+
+	f.From, f.To = first, m.last()
+	aggs, err := aggregateSessions(ctx, exec, bucketDay, f)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range aggs {
+		if d, err := time.Parse(DateYMD, a.Bucket); err == nil {
+			m.Mins[d.Day()] = a.Total
+		}
+	}
+
+	notes, err := models.Calendars(
+		qm.Where("date(date) BETWEEN ? AND ?", from, to),
+		qm.OrderBy("date ASC, id ASC"),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range notes {
+		m.Notes[c.Date.Time.Day()] = append(m.Notes[c.Date.Time.Day()], c)
+	}
+
+	taskMods := withTaskTag("id", f.Tag)
+	if f.Task != 0 {
+		taskMods = append(taskMods, qm.Where("id = ?", f.Task))
+	}
+	tasks, err := models.Tasks(taskMods...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]any, 0, len(tasks))
+	for _, t := range tasks {
+		m.Names[t.ID.Int64] = t.Name
+		ids = append(ids, t.ID.Int64)
+		if t.Target.Valid && !t.Target.Time.Before(first) && !t.Target.Time.After(m.last()) {
+			m.Targets[t.Target.Time.Day()] = append(m.Targets[t.Target.Time.Day()], t.Name)
+		}
+	}
+	if len(ids) == 0 {
+		return m, nil
+	}
+
+	milestones, err := models.Milestones(
+		qm.Where("date(done) BETWEEN ? AND ?", from, to),
+		qm.WhereIn("task IN ?", ids...),
+		qm.OrderBy("done ASC, id ASC"),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	for _, ms := range milestones {
+		label := m.Names[ms.Task] + ": " + ms.Type.String
+		m.Milestones[ms.Done.Time.Day()] = append(m.Milestones[ms.Done.Time.Day()], label)
+	}
+	return m, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const calCellWidth = 5
+
+// renderCalMonth draws the month grid; selected (a day of month, 0 for none) is highlighted.
+// Without color the shade becomes a trailing block character.
+func renderCalMonth(m *calMonth, weekStart time.Weekday, selected int, color bool) string {
+	var b strings.Builder
+	width := 7 * calCellWidth
+
+	title := m.First.Format("January 2006")
+	fmt.Fprintf(&b, "%s%s\n", strings.Repeat(" ", (width-len(title))/2), title)
+	for i := 0; i < 7; i++ {
+		d := time.Weekday((int(weekStart) + i) % 7)
+		fmt.Fprintf(&b, " %-*s", calCellWidth-1, d.String()[:2])
+	}
+	b.WriteString("\n")
+
+	var max int64
+	for _, v := range m.Mins {
+		if v > max {
+			max = v
+		}
+	}
+
+	now := today()
+	lead := (int(m.First.Weekday()) - int(weekStart) + 7) % 7
+	b.WriteString(strings.Repeat(" ", lead*calCellWidth))
+	col := lead
+	for day := 1; day <= m.days(); day++ {
+		level := heatLevel(m.Mins[day], max)
+		isToday := now.Equal(m.First.AddDate(0, 0, day-1))
+
+		num := fmt.Sprintf("%3d", day)
+		switch {
+		case color && day == selected:
+			num = "\x1b[7m" + num + "\x1b[0m"
+		case color && isToday:
+			num = heatBg(level, "\x1b[1;4m"+num)
+		case color:
+			num = heatBg(level, num)
+		case day == selected:
+			num = fmt.Sprintf(">%2d", day)
+		}
+
+		shade := " "
+		if !color && level > 0 {
+			shade = heatRunes[level]
+		}
+		b.WriteString(num + m.mark(day) + shade)
+
+		col++
+		if col == 7 {
+			b.WriteString("\n")
+			col = 0
+		}
+	}
+	if col != 0 {
+		b.WriteString("\n")
+	}
+
+	legend := fmt.Sprintf("%s note  %s milestone  %s target", calMarkNote, calMarkMilestone, calMarkTarget)
+	if max > 0 {
+		legend += fmt.Sprintf("  max %d min", max)
+	}
+	b.WriteString(legend + "\n")
+	return b.String()
+}
+
+// mark returns the highest-priority marker for a day, or a space.
+func (m *calMonth) mark(day int) string {
+	switch {
+	case len(m.Targets[day]) > 0:
+		return calMarkTarget
+	case len(m.Milestones[day]) > 0:
+		return calMarkMilestone
+	case len(m.Notes[day]) > 0:
+		return calMarkNote
+	}
+	return " "
+}
+
+// renderCalEvents lists the month's notes, milestones and targets plus a minutes summary.
+func renderCalEvents(m *calMonth) string {
+	var b strings.Builder
+	var total int64
+	for _, v := range m.Mins {
+		total += v
+	}
+	fmt.Fprintf(&b, "%d min over %s\n", total, plural(len(m.Mins), "active day"))
+
+	var days []int
+	for day := 1; day <= m.days(); day++ {
+		if len(m.Targets[day])+len(m.Milestones[day])+len(m.Notes[day]) > 0 {
+			days = append(days, day)
+		}
+	}
+	sort.Ints(days)
+	if len(days) > 0 {
+		b.WriteString("\n")
+	}
+	for _, day := range days {
+		b.WriteString(calDayEvents(m, day, fmt.Sprintf("%02d ", day)))
+	}
+	return b.String()
+}
+
+func calDayEvents(m *calMonth, day int, prefix string) string {
+	var b strings.Builder
+	for _, c := range m.Notes[day] {
+		fmt.Fprintf(&b, "%s%s %s: %s\n", prefix, calMarkNote, c.Kind, c.Note)
+	}
+	for _, s := range m.Milestones[day] {
+		fmt.Fprintf(&b, "%s%s %s\n", prefix, calMarkMilestone, s)
+	}
+	for _, s := range m.Targets[day] {
+		fmt.Fprintf(&b, "%s%s target %s\n", prefix, calMarkTarget, s)
+	}
+	return b.String()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// calModel browses months and days; enter shows the selected day's sessions and notes.
+type calModel struct {
+	filter    sessionFilter
+	weekStart time.Weekday
+	color     bool
+
+	month  *calMonth
+	sel    time.Time
+	detail bool
+	err    error
+}
+
+func (m *calModel) Init() tea.Cmd { return nil }
+
+func (m *calModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch key.String() {
+	case "q", "esc", "ctrl+c":
+		return m, tea.Quit
+	case "enter", " ":
+		m.detail = !m.detail
+	case "left", "h":
+		m.move(m.sel.AddDate(0, 0, -1))
+	case "right", "l":
+		m.move(m.sel.AddDate(0, 0, 1))
+	case "up", "k":
+		m.move(m.sel.AddDate(0, 0, -7))
+	case "down", "j":
+		m.move(m.sel.AddDate(0, 0, 7))
+	case "p", "pgup", "[":
+		m.move(addMonthsClamped(m.sel, -1))
+	case "n", "pgdown", "]":
+		m.move(addMonthsClamped(m.sel, 1))
+	case "t":
+		m.move(today())
+	}
+	if m.err != nil {
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// move selects day, reloading when it falls in another month.
+func (m *calModel) move(day time.Time) {
+	m.sel = day
+	if day.Year() == m.month.First.Year() && day.Month() == m.month.First.Month() {
+		return
+	}
+	first := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	month, err := loadCalMonth(db.Ctx(), db.Conn, first, m.filter)
+	if err != nil {
+		m.err = err
+		return
+	}
+	m.month = month
+}
+
+func (m *calModel) View() string {
+	var b strings.Builder
+	b.WriteString(renderCalMonth(m.month, m.weekStart, m.sel.Day(), m.color))
+	b.WriteString("\n")
+
+	if m.detail {
+		b.WriteString(m.dayDetail())
+		b.WriteString("\n")
+	}
+	b.WriteString("←→↑↓ day  [ ] month  t today  enter details  q quit\n")
+	return b.String()
+}
+
+// dayDetail lists the selected day's sessions followed by its notes, milestones and targets.
+func (m *calModel) dayDetail() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", m.sel.Format("Monday 2006-01-02"))
+
+	sessions, err := daySessions(db.Ctx(), db.Conn, m.sel, m.filter)
+	if err != nil {
+		return fmt.Sprintf("load sessions: %v\n", err)
+	}
+	if len(sessions) == 0 {
+		b.WriteString("  no sessions\n")
+	}
+	for _, s := range sessions {
+		fmt.Fprintf(&b, "  %-16s %4d min", m.month.Names[s.Task], s.Mins.Int64)
+		if s.Feedback.Valid {
+			fmt.Fprintf(&b, "  score %d", s.Feedback.Int64)
+		}
+		if s.Notes.Valid && s.Notes.String != "" {
+			fmt.Fprintf(&b, "  %s", s.Notes.String)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(calDayEvents(m.month, m.sel.Day(), "  "))
+	return b.String()
+}
+
+// daySessions loads one day's sessions, honoring the task and tag filter.
+func daySessions(ctx context.Context, exec boil.ContextExecutor, day time.Time, f sessionFilter) (models.SessionSlice, error) {
+	mods := []qm.QueryMod{
+		qm.Where("date(date) = ?", day.Format(DateYMD)),
+		qm.OrderBy("id ASC"),
+	}
+	if f.Task != 0 {
+		mods = append(mods, qm.Where("task = ?", f.Task))
+	}
+	return models.Sessions(withTaskTag("task", f.Tag, mods...)...).All(ctx, exec)
+}
+
+// addMonthsClamped moves by n months, keeping the day within the target month.
+func addMonthsClamped(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aarondl/null/v8"
@@ -41,34 +42,47 @@ import (
 //   id INTEGER PK
 //   date DATE            → null.Time (nullable)
 //   note TEXT NOT NULL   → string
+//   kind TEXT NOT NULL   → string (note | break | holiday; breaks and holidays excuse streaks)
 
 var calendarCmd = &cobra.Command{
 	Use:   "calendar",
-	Short: "Manage calendar notes (list, rm via CLI; add/edit via TUI)",
+	Short: "Manage calendar notes (list, rm via CLI; add/edit via flags or TUI)",
 }
 
 var calendarAddCmd = &cobra.Command{
 	Use:   "add",
-	Short: "Interactive TUI to add a new calendar note",
+	Short: "Add a new calendar note (flags or interactive TUI)",
 	Run:   runCalendarAdd,
 }
 
 var calendarEditCmd = &cobra.Command{
 	Use:   "edit [id]",
-	Short: "Interactive TUI to edit a calendar note",
+	Short: "Edit a calendar note (flags or interactive TUI)",
 	Args:  cobra.ExactArgs(1),
 	Run:   runCalendarEdit,
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
+const (
+	calendarNote    = "note"
+	calendarBreak   = "break"
+	calendarHoliday = "holiday"
+)
+
+var calendarKinds = []string{calendarNote, calendarBreak, calendarHoliday}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
 func init() {
 	rootCmd.AddCommand(calendarCmd)
 	calendarCmd.AddCommand(calendarAddCmd, calendarEditCmd)
+	BindFieldFlags(calendarAddCmd, calendarFields(&models.Calendar{}))
+	BindFieldFlags(calendarEditCmd, calendarFields(&models.Calendar{}))
 
 	// cmd/cmdCalendar.go (inside init(): RegisterCrudSubcommands for calendar)
 
-	RegisterCrudSubcommands(calendarCmd, "sisu.db", CrudModel[*models.Calendar]{
+	RegisterCrudSubcommands(calendarCmd, resolveDBPath, CrudModel[*models.Calendar]{
 		Singular: "calendar",
 
 		ListFn: func(ctx context.Context, conn *sql.DB) ([]*models.Calendar, error) {
@@ -80,10 +94,10 @@ func init() {
 			if c.Date.Valid {
 				date = c.Date.Time.Format(time.RFC3339)
 			}
-			return c.ID.Int64, fmt.Sprintf("date=%s note=%s", date, c.Note)
+			return c.ID.Int64, fmt.Sprintf("date=%s kind=%s note=%s", date, c.Kind, c.Note)
 		},
 
-		TableHeaders: []string{"id", "date", "note"},
+		TableHeaders: []string{"id", "date", "kind", "note"},
 		TableRow: func(c *models.Calendar) []string {
 			date := ""
 			if c.Date.Valid {
@@ -92,6 +106,7 @@ func init() {
 			return []string{
 				strconv.FormatInt(c.ID.Int64, 10),
 				date,
+				c.Kind,
 				c.Note,
 			}
 		},
@@ -109,15 +124,34 @@ func init() {
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func runCalendarAdd(_ *cobra.Command, _ []string) {
-	entry := &models.Calendar{}
+func calendarFields(entry *models.Calendar) []Field {
+	return []Field{
+		FOptDate("Date (YYYY-MM-DD, optional)", "Date", OptTimeInitial(entry.Date, DateYMD)),
+		FString("Note", "Note", entry.Note),
+		FString(fmt.Sprintf("Kind (%s)", strings.Join(calendarKinds, "/")), "Kind", entry.Kind,
+			WithValidate(VCalendarKind),
+			WithParse(func(s string) (any, error) { return strings.ToLower(strings.TrimSpace(s)), nil }),
+		),
+	}
+}
 
-	fields := []Field{
-		FOptDate("Date (YYYY-MM-DD, optional)", "Date", ""),
-		FString("Note", "Note", ""),
+// VCalendarKind accepts one of calendarKinds, case-insensitive.
+func VCalendarKind(s string) error {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, k := range calendarKinds {
+		if s == k {
+			return nil
+		}
 	}
+	return fmt.Errorf("kind must be one of %s", strings.Join(calendarKinds, ", "))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runCalendarAdd(cmd *cobra.Command, _ []string) {
+	entry := &models.Calendar{Kind: calendarNote}
 
-	RunFormWizard(fields, entry)
+	RunForm(cmd, calendarFields(entry), entry)
 
 	if err := entry.Insert(context.Background(), db.Conn, boil.Infer()); err != nil {
 		log.Fatalf("insert calendar entry: %v", err)
@@ -127,7 +161,7 @@ func runCalendarAdd(_ *cobra.Command, _ []string) {
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func runCalendarEdit(_ *cobra.Command, args []string) {
+func runCalendarEdit(cmd *cobra.Command, args []string) {
 	rawID := args[0]
 	idNum, err := strconv.ParseInt(rawID, 10, 64)
 	if err != nil {
@@ -139,14 +173,9 @@ func runCalendarEdit(_ *cobra.Command, args []string) {
 		log.Fatalf("find calendar %d: %v", idNum, err)
 	}
 
-	fields := []Field{
-		FOptDate("Date (YYYY-MM-DD, optional)", "Date", OptTimeInitial(entry.Date, DateYMD)),
-		FString("Note", "Note", entry.Note),
-	}
-
-	RunFormWizard(fields, entry)
+	RunEditForm(cmd, calendarFields(entry), entry)
 
-	if _, err := entry.Update(context.Background(), db.Conn, boil.Whitelist("date", "note")); err != nil {
+	if _, err := entry.Update(context.Background(), db.Conn, boil.Whitelist("date", "note", "kind")); err != nil {
 		log.Fatalf("update calendar entry: %v", err)
 	}
 	fmt.Printf("Updated calendar %d\n", entry.ID.Int64)
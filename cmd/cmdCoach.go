@@ -23,7 +23,10 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"math"
+	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aarondl/null/v8"
@@ -39,35 +42,93 @@ import (
 
 // Schema (coach):
 //   id INTEGER PK
-//   trigger TEXT NOT NULL   → string (required)
-//   content TEXT NOT NULL   → string (required)
+//   trigger TEXT NOT NULL   → string (required, a rule such as "streak >= 7")
+//   content TEXT NOT NULL   → string (required, a text/template over coachData)
 //   date DATE               → null.Time (optional)
+//   task INTEGER FK         → null.Int64 (optional, NULL applies to every task)
+//   cooldown INTEGER        → int64 (days between deliveries to a task, 0 for none)
+//
+// Schema (coach_deliveries): coach, task, delivered, content; written by `coach check`
 
 var coachCmd = &cobra.Command{
 	Use:   "coach",
-	Short: "Manage coach triggers (list, rm via CLI; add/edit via TUI)",
+	Short: "Manage coach triggers (list, rm via CLI; add/edit via flags or TUI)",
 }
 
 var coachAddCmd = &cobra.Command{
 	Use:   "add",
-	Short: "Interactive TUI to add a new coach entry",
+	Short: "Add a new coach entry (flags or interactive TUI)",
 	Run:   runCoachAdd,
 }
 
 var coachEditCmd = &cobra.Command{
 	Use:   "edit [id]",
-	Short: "Interactive TUI to edit a coach entry",
+	Short: "Edit a coach entry (flags or interactive TUI)",
 	Args:  cobra.ExactArgs(1),
 	Run:   runCoachEdit,
 }
 
+var coachCheckCmd = &cobra.Command{
+	Use:               "check [task]",
+	Short:             "Evaluate coach rules against live stats and print what fires",
+	Long:              helpCoachCheck,
+	Example:           exampleCoachCheck,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTaskArg,
+	Run:               runCoachCheck,
+}
+
+var coachHistoryCmd = &cobra.Command{
+	Use:               "history [task]",
+	Short:             "Show which coach messages were delivered and when",
+	Long:              helpCoachHistory,
+	Example:           exampleCoachHistory,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTaskArg,
+	Run:               runCoachHistory,
+}
+
+var coachPreviewCmd = &cobra.Command{
+	Use:     "preview <id>",
+	Short:   "Render a coach message against a task's live stats",
+	Long:    helpCoachPreview,
+	Example: exampleCoachPreview,
+	Args:    cobra.ExactArgs(1),
+	Run:     runCoachPreview,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	flagCoachCheckTag    string
+	flagCoachCheckVars   bool
+	flagCoachCheckAll    bool
+	flagCoachCheckDryRun bool
+	flagCoachPreviewTask string
+	flagCoachHistoryLast int
+)
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func init() {
 	rootCmd.AddCommand(coachCmd)
-	coachCmd.AddCommand(coachAddCmd, coachEditCmd)
+	coachCmd.AddCommand(coachAddCmd, coachEditCmd, coachCheckCmd, coachPreviewCmd, coachHistoryCmd)
+	BindFieldFlags(coachAddCmd, coachFields(&models.Coach{}))
+	BindFieldFlags(coachEditCmd, coachFields(&models.Coach{}))
+
+	coachCheckCmd.Flags().StringVar(&flagCoachCheckTag, "tag", "", "only check tasks with this tag")
+	coachCheckCmd.Flags().BoolVar(&flagCoachCheckVars, "vars", false, "also print each task's rule variables")
+	coachCheckCmd.Flags().BoolVar(&flagCoachCheckAll, "all", false, "list every rule that fires, ignoring cooldowns and rotation, without recording deliveries")
+	coachCheckCmd.Flags().BoolVar(&flagCoachCheckDryRun, "dry-run", false, "pick messages without recording their delivery")
+	_ = coachCheckCmd.RegisterFlagCompletionFunc("tag", completeTagNames)
+
+	coachPreviewCmd.Flags().StringVar(&flagCoachPreviewTask, "task", "", "task to render against (ID or name)")
+	_ = coachPreviewCmd.MarkFlagRequired("task")
+	_ = coachPreviewCmd.RegisterFlagCompletionFunc("task", completeTaskArg)
 
-	RegisterCrudSubcommands(coachCmd, "sisu.db", CrudModel[*models.Coach]{
+	coachHistoryCmd.Flags().IntVar(&flagCoachHistoryLast, "last", 20, "show this many most recent deliveries (0 for all)")
+
+	RegisterCrudSubcommands(coachCmd, resolveDBPath, CrudModel[*models.Coach]{
 		Singular: "coach",
 
 		ListFn: func(ctx context.Context, conn *sql.DB) ([]*models.Coach, error) {
@@ -79,19 +140,26 @@ func init() {
 			if c.Date.Valid {
 				date = c.Date.Time.Format(time.RFC3339)
 			}
-			return c.ID.Int64, fmt.Sprintf("trigger=%s content=%s date=%s", c.Trigger, c.Content, date)
+			return c.ID.Int64, fmt.Sprintf("trigger=%s content=%s date=%s task=%s cooldown=%d",
+				c.Trigger, c.Content, date, OptInt64Initial(c.Task), c.Cooldown)
 		},
 
-		TableHeaders: []string{"id", "trigger", "content", "date"},
+		TableHeaders: []string{"id", "task", "trigger", "content", "cooldown", "date"},
 		TableRow: func(c *models.Coach) []string {
 			date := ""
 			if c.Date.Valid {
 				date = c.Date.Time.Format(time.RFC3339)
 			}
+			task := "all"
+			if c.Task.Valid {
+				task = strconv.FormatInt(c.Task.Int64, 10)
+			}
 			return []string{
 				strconv.FormatInt(c.ID.Int64, 10),
+				task,
 				c.Trigger,
 				c.Content,
+				plural(int(c.Cooldown), "day"),
 				date,
 			}
 		},
@@ -101,6 +169,9 @@ func init() {
 			if err != nil {
 				return err
 			}
+			if _, err := models.CoachDeliveries(qm.Where("coach = ?", id)).DeleteAll(ctx, conn); err != nil {
+				return err
+			}
 			_, err = row.Delete(ctx, conn)
 			return err
 		},
@@ -110,18 +181,36 @@ func init() {
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func runCoachAdd(_ *cobra.Command, _ []string) {
-	entry := &models.Coach{}
+func coachFields(entry *models.Coach) []Field {
+	return []Field{
+		FString("Trigger rule (e.g. days_since_last_session >= 3)", "Trigger", entry.Trigger, WithValidate(VRule)),
+		FString("Content (template, e.g. {{.Task.Name}}: {{.Streak}} days)", "Content", entry.Content, WithValidate(VCoachContent)),
+		FOptDate("Date (YYYY-MM-DD, optional)", "Date", OptTimeInitial(entry.Date, DateYMD)),
+		FOptInt("Task (ID or name, blank for every task)", "Task", OptInt64Initial(entry.Task), WithParse(parseOptTaskRef)),
+		FInt("Cooldown (days between deliveries, 0 for none)", "Cooldown", strconv.FormatInt(entry.Cooldown, 10), WithValidate(VIntRange(0, 365))),
+	}
+}
 
-	fields := []Field{
-		FString("Trigger", "Trigger", ""),
-		FString("Content", "Content", ""),
-		FOptDate("Date (YYYY-MM-DD, optional)", "Date", ""),
+// parseOptTaskRef resolves an optional task ID or name into a nullable FK.
+func parseOptTaskRef(s string) (any, error) {
+	if strings.TrimSpace(s) == "" {
+		return null.Int64{}, nil
+	}
+	task, err := resolveTask(db.Ctx(), db.Conn, s)
+	if err != nil {
+		return nil, err
 	}
+	return null.Int64From(task.ID.Int64), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
 
-	RunFormWizard(fields, entry)
+func runCoachAdd(cmd *cobra.Command, _ []string) {
+	entry := &models.Coach{Cooldown: 1}
 
-	if err := entry.Insert(context.Background(), db.Conn, boil.Infer()); err != nil {
+	RunForm(cmd, coachFields(entry), entry)
+
+	if err := entry.Insert(context.Background(), db.Conn, boil.Greylist("cooldown")); err != nil {
 		log.Fatalf("insert coach entry: %v", err)
 	}
 	fmt.Printf("Created coach %d\n", entry.ID.Int64)
@@ -129,7 +218,7 @@ func runCoachAdd(_ *cobra.Command, _ []string) {
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func runCoachEdit(_ *cobra.Command, args []string) {
+func runCoachEdit(cmd *cobra.Command, args []string) {
 	idNum, err := strconv.ParseInt(args[0], 10, 64)
 	if err != nil {
 		log.Fatalf("invalid coach ID: %v", err)
@@ -140,18 +229,207 @@ func runCoachEdit(_ *cobra.Command, args []string) {
 		log.Fatalf("find coach %d: %v", idNum, err)
 	}
 
-	fields := []Field{
-		FString("Trigger", "Trigger", entry.Trigger),
-		FString("Content", "Content", entry.Content),
-		FOptDate("Date (YYYY-MM-DD, optional)", "Date", OptTimeInitial(entry.Date, DateYMD)),
-	}
-
-	RunFormWizard(fields, entry)
+	RunEditForm(cmd, coachFields(entry), entry)
 
-	if _, err := entry.Update(context.Background(), db.Conn, boil.Whitelist("trigger", "content", "date")); err != nil {
+	if _, err := entry.Update(context.Background(), db.Conn, boil.Whitelist("trigger", "content", "date", "task", "cooldown")); err != nil {
 		log.Fatalf("update coach entry: %v", err)
 	}
 	fmt.Printf("Updated coach %d\n", entry.ID.Int64)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runCoachCheck(_ *cobra.Command, args []string) {
+	ctx := db.Ctx()
+
+	rules, err := loadCoachRules(ctx, db.Conn, os.Stderr)
+	if err != nil {
+		log.Fatalf("list coach entries: %v", err)
+	}
+
+	var tasks models.TaskSlice
+	if len(args) == 1 {
+		task, err := resolveTask(ctx, db.Conn, args[0])
+		if err != nil {
+			log.Fatalf("resolve task: %v", err)
+		}
+		tasks = models.TaskSlice{task}
+	} else {
+		tasks, err = models.Tasks(withTaskTag("id", flagCoachCheckTag,
+			qm.Where("coalesce(archived, 0) = 0"),
+			qm.OrderBy("id ASC"),
+		)...).All(ctx, db.Conn)
+		if err != nil {
+			log.Fatalf("list tasks: %v", err)
+		}
+	}
+
+	streakRules, err := defaultStreakRules(ctx, db.Conn)
+	if err != nil {
+		log.Fatalf("load streak rules: %v", err)
+	}
+
+	now := time.Now()
+	var rows [][]string
+	for _, t := range tasks {
+		if flagCoachCheckVars {
+			vars, err := taskRuleVars(ctx, db.Conn, t, streakRules, now)
+			if err != nil {
+				log.Fatalf("stats for task %d: %v", t.ID.Int64, err)
+			}
+			fmt.Printf("%s: %s\n", t.Name, formatRuleVars(vars))
+		}
+
+		fired, err := fireCoach(ctx, db.Conn, rules, t, streakRules, now)
+		if err != nil {
+			log.Fatalf("coach for task %d: %v", t.ID.Int64, err)
+		}
+		if !flagCoachCheckAll {
+			// one message per task: the least recently shown that is not resting
+			if len(fired) == 0 || fired[0].Resting {
+				continue
+			}
+			fired = fired[:1]
+			if !flagCoachCheckDryRun {
+				if err := deliverCoach(ctx, db.Conn, t.ID.Int64, fired[0], now); err != nil {
+					log.Fatalf("record coach delivery: %v", err)
+				}
+			}
+		}
+		for _, f := range fired {
+			row := []string{
+				strconv.FormatInt(f.Entry.ID.Int64, 10),
+				t.Name,
+				f.Entry.Trigger,
+				f.Content,
+			}
+			if flagCoachCheckAll {
+				row = append(row, coachLastShown(f, now))
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	if len(rows) == 0 {
+		fmt.Println("No coach messages due.")
+		return
+	}
+	headers := []string{"id", "task", "trigger", "content"}
+	if flagCoachCheckAll {
+		headers = append(headers, "last shown")
+	}
+	fmt.Println(RenderTable(headers, rows))
+}
+
+// coachLastShown describes a fired rule's delivery state for --all.
+func coachLastShown(f firedCoach, now time.Time) string {
+	if f.Last.IsZero() {
+		return "never"
+	}
+	last := f.Last.Format(DateYMD)
+	if f.Resting {
+		until := dateOnly(f.Last).AddDate(0, 0, int(f.Entry.Cooldown))
+		return fmt.Sprintf("%s (resting %s)", last, plural(daysBetween(now, until), "more day"))
+	}
+	return last
+}
+
+// formatRuleVars lists the variables by name; missing values print as "-".
+func formatRuleVars(vars map[string]float64) string {
+	parts := make([]string, 0, len(vars))
+	for _, name := range ruleVarNames() {
+		v := "-"
+		if x := vars[name]; !math.IsNaN(x) {
+			v = strconv.FormatFloat(x, 'f', -1, 64)
+			if x != math.Trunc(x) {
+				v = strconv.FormatFloat(x, 'f', 2, 64)
+			}
+		}
+		parts = append(parts, name+"="+v)
+	}
+	return strings.Join(parts, " ")
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runCoachPreview(_ *cobra.Command, args []string) {
+	ctx := db.Ctx()
+
+	idNum, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		log.Fatalf("invalid coach ID: %v", err)
+	}
+	entry, err := models.FindCoach(ctx, db.Conn, null.Int64From(idNum))
+	if err != nil {
+		log.Fatalf("find coach %d: %v", idNum, err)
+	}
+	task, err := resolveTask(ctx, db.Conn, flagCoachPreviewTask)
+	if err != nil {
+		log.Fatalf("resolve task: %v", err)
+	}
+	streakRules, err := defaultStreakRules(ctx, db.Conn)
+	if err != nil {
+		log.Fatalf("load streak rules: %v", err)
+	}
+
+	data, err := coachDataFor(ctx, db.Conn, task, streakRules, time.Now())
+	if err != nil {
+		log.Fatalf("coach data for task %d: %v", task.ID.Int64, err)
+	}
+	content, err := renderCoach(entry.Content, data)
+	if err != nil {
+		log.Fatalf("render coach %d: %v", idNum, err)
+	}
+	fmt.Println(content)
+
+	fires := "does not fire"
+	if r, err := parseRule(entry.Trigger); err != nil {
+		fires = fmt.Sprintf("is invalid: %v", err)
+	} else if ruleFires(r, data.Vars) {
+		fires = "fires"
+	}
+	fmt.Printf("\n(trigger %q %s for %s)\n", entry.Trigger, fires, task.Name)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runCoachHistory(_ *cobra.Command, args []string) {
+	ctx := db.Ctx()
+
+	mods := []qm.QueryMod{qm.OrderBy("delivered DESC, id DESC")}
+	if len(args) == 1 {
+		task, err := resolveTask(ctx, db.Conn, args[0])
+		if err != nil {
+			log.Fatalf("resolve task: %v", err)
+		}
+		mods = append(mods, qm.Where("task = ?", task.ID.Int64))
+	}
+	if flagCoachHistoryLast > 0 {
+		mods = append(mods, qm.Limit(flagCoachHistoryLast))
+	}
+	deliveries, err := models.CoachDeliveries(mods...).All(ctx, db.Conn)
+	if err != nil {
+		log.Fatalf("list coach deliveries: %v", err)
+	}
+	if len(deliveries) == 0 {
+		fmt.Println("No coach messages delivered yet.")
+		return
+	}
+
+	names, err := taskNames(ctx, db.Conn)
+	if err != nil {
+		log.Fatalf("list tasks: %v", err)
+	}
+	rows := make([][]string, 0, len(deliveries))
+	for _, d := range deliveries {
+		rows = append(rows, []string{
+			d.Delivered.Local().Format("2006-01-02 15:04"),
+			names[d.Task],
+			strconv.FormatInt(d.Coach, 10),
+			d.Content,
+		})
+	}
+	fmt.Println(RenderTable([]string{"delivered", "task", "coach", "content"}, rows))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,218 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/DanielRivasMD/Sisu/db"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var configCmd = &cobra.Command{
+	Use:     "config",
+	Short:   "Inspect and edit sisu configuration",
+	Long:    helpConfig,
+	Example: exampleConfig,
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the config file location",
+	Args:  cobra.NoArgs,
+	Run:   runConfigPath,
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show resolved configuration and where each value came from",
+	Args:  cobra.NoArgs,
+	Run:   runConfigShow,
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open the config file in $VISUAL / $EDITOR",
+	Args:  cobra.NoArgs,
+	Run:   runConfigEdit,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:               "get <key>",
+	Short:             "Print a setting (stored value or default)",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSettingKeys,
+	PreRun:            dbPreRun,
+	PostRun:           dbPostRun,
+	Run:               runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:               "set <key> <value>",
+	Short:             "Store a setting in the database",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeSettingKeys,
+	PreRun:            dbPreRun,
+	PostRun:           dbPostRun,
+	Run:               runConfigSet,
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:               "unset <key>...",
+	Short:             "Remove stored settings so their defaults apply",
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeSettingKeys,
+	PreRun:            dbPreRun,
+	PostRun:           dbPostRun,
+	Run:               runConfigUnset,
+}
+
+var configListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List every setting with its value and default",
+	Args:    cobra.NoArgs,
+	PreRun:  dbPreRun,
+	PostRun: dbPostRun,
+	Run:     runConfigList,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// written by `config edit` when no config file exists yet
+const configTemplate = `# sisu configuration
+
+# sqlite database location; overridden by $SISU_DB and --db
+# db = "~/.local/share/sisu/sisu.db"
+`
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configPathCmd, configShowCmd, configEditCmd)
+	configCmd.AddCommand(configGetCmd, configSetCmd, configUnsetCmd, configListCmd)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runConfigPath(cmd *cobra.Command, args []string) {
+	fmt.Println(configFilePath())
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) {
+	found := "not found"
+	if _, err := os.Stat(configFilePath()); err == nil {
+		found = "found"
+	}
+	fmt.Printf("config: %s (%s)\n", configFilePath(), found)
+	fmt.Printf("db:     %s (%s)\n", expandHome(viper.GetString(keyDB)), dbPathSource())
+}
+
+func runConfigEdit(cmd *cobra.Command, args []string) {
+	path := configFilePath()
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := os.MkdirAll(configDir(), 0o755); err != nil {
+			log.Fatalf("create config directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(configTemplate), 0o644); err != nil {
+			log.Fatalf("write config: %v", err)
+		}
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	c := exec.Command(editor, path)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := c.Run(); err != nil {
+		log.Fatalf("run %s: %v", editor, err)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runConfigGet(_ *cobra.Command, args []string) {
+	v, _, err := getSetting(db.Ctx(), db.Conn, args[0])
+	if err != nil {
+		log.Fatalf("config get: %v", err)
+	}
+	fmt.Println(v)
+}
+
+func runConfigSet(_ *cobra.Command, args []string) {
+	v, err := setSetting(db.Ctx(), db.Conn, args[0], args[1])
+	if err != nil {
+		log.Fatalf("config set: %v", err)
+	}
+	fmt.Printf("Set %s = %q\n", args[0], v)
+}
+
+func runConfigUnset(_ *cobra.Command, args []string) {
+	for _, key := range args {
+		if err := unsetSetting(db.Ctx(), db.Conn, key); err != nil {
+			log.Fatalf("config unset: %v", err)
+		}
+		fmt.Printf("Unset %s (default %q)\n", key, settings[key].Default)
+	}
+}
+
+func runConfigList(_ *cobra.Command, _ []string) {
+	rows := make([][]string, 0, len(settings))
+	for _, key := range settingKeys() {
+		v, stored, err := getSetting(db.Ctx(), db.Conn, key)
+		if err != nil {
+			log.Fatalf("config list: %v", err)
+		}
+		source := "default"
+		if stored {
+			source = "set"
+		}
+		rows = append(rows, []string{key, v, settings[key].Default, source, settings[key].Help})
+	}
+	fmt.Println(RenderTable([]string{"key", "value", "default", "source", "description"}, rows))
+}
+
+// completeSettingKeys offers keys for the first argument (every argument for unset).
+func completeSettingKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 && cmd.Name() != "unset" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	comps := make([]string, 0, len(settings))
+	for _, key := range settingKeys() {
+		if strings.HasPrefix(key, toComplete) {
+			comps = append(comps, key+"\t"+settings[key].Help)
+		}
+	}
+	return comps, cobra.ShellCompDirectiveNoFileComp
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,330 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Sisu/db"
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var dayoffCmd = &cobra.Command{
+	Use:               "dayoff",
+	Short:             "Recommend a rest day from feedback, load and consecutive training days",
+	Long:              helpDayoff,
+	Example:           exampleDayoff,
+	Args:              cobra.NoArgs,
+	PersistentPreRun:  dbPreRun,
+	PersistentPostRun: dbPostRun,
+	Run:               runDayoff,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	flagDayoffTask  string
+	flagDayoffTag   string
+	flagDayoffOn    string
+	flagDayoffYes   bool
+	flagDayoffForce bool
+)
+
+// Signals compare the last dayoffRecent days against the dayoffBaseline days before them.
+const (
+	dayoffRecent    = 7
+	dayoffBaseline  = 28
+	dayoffLoadRatio = 1.3 // recent minutes over the baseline weekly mean
+	dayoffRunDays   = 6   // consecutive training days worth one point, twice that worth two
+	dayoffThreshold = 2   // points needed to recommend rest
+	dayoffReviews   = 14  // days of finished reviews weighed, about two weekly cycles
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(dayoffCmd)
+
+	dayoffCmd.Flags().StringVar(&flagDayoffTask, "task", "", "only weigh this task (ID or name)")
+	dayoffCmd.Flags().StringVar(&flagDayoffTag, "tag", "", "only weigh tasks with this tag")
+	dayoffCmd.Flags().StringVar(&flagDayoffOn, "on", "", "rest day to book (default today, or tomorrow if you already trained today)")
+	dayoffCmd.Flags().BoolVarP(&flagDayoffYes, "yes", "y", false, "book the rest day without asking")
+	dayoffCmd.Flags().BoolVar(&flagDayoffForce, "force", false, "offer the rest day even when it is not recommended")
+
+	_ = dayoffCmd.RegisterFlagCompletionFunc("task", completeTaskArg)
+	_ = dayoffCmd.RegisterFlagCompletionFunc("tag", completeTagNames)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runDayoff(_ *cobra.Command, _ []string) {
+	ctx := db.Ctx()
+	now := time.Now()
+
+	f := sessionFilter{Tag: flagDayoffTag}
+	if flagDayoffTask != "" {
+		task, err := resolveTask(ctx, db.Conn, flagDayoffTask)
+		if err != nil {
+			log.Fatalf("resolve task: %v", err)
+		}
+		f.Task = task.ID.Int64
+	}
+
+	excused, err := excusedDays(ctx, db.Conn)
+	if err != nil {
+		log.Fatalf("load calendar: %v", err)
+	}
+	a, err := assessDayoff(ctx, db.Conn, f, excused, settingInt(settingFeedbackScale), now)
+	if err != nil {
+		log.Fatalf("assess day off: %v", err)
+	}
+
+	fmt.Printf("Last %d days against the %d before:\n", dayoffRecent, dayoffBaseline)
+	for _, s := range a.Signals {
+		mark := "·"
+		if s.Points > 0 {
+			mark = "✓"
+		}
+		fmt.Printf("  %s %s\n", mark, s.Detail)
+	}
+	fmt.Println()
+
+	day := today()
+	if a.TrainedToday {
+		day = day.AddDate(0, 0, 1)
+	}
+	if flagDayoffOn != "" {
+		if day, err = parseRelativeDate(flagDayoffOn, now); err != nil {
+			log.Fatalf("parse --on: %v", err)
+		}
+	}
+
+	if !a.Recommend() && !flagDayoffForce {
+		fmt.Printf("No rest day needed (%d of %d points).\n", a.Points(), dayoffThreshold)
+		return
+	}
+	if excused[day] {
+		fmt.Printf("%s is already a break or holiday.\n", day.Format(DateYMD))
+		return
+	}
+	if a.Recommend() {
+		fmt.Printf("Rest recommended (%d of %d points): take %s off.\n", a.Points(), dayoffThreshold, day.Format(DateYMD))
+	}
+
+	if !flagDayoffYes && !confirm(fmt.Sprintf("Book %s as a rest day?", day.Format(DateYMD)), false) {
+		if !stdinIsTTY() {
+			fmt.Println("Run with --yes to book it.")
+		}
+		return
+	}
+
+	entry := &models.Calendar{
+		Date: null.TimeFrom(day),
+		Kind: calendarBreak,
+		Note: a.Note(),
+	}
+	if err := entry.Insert(ctx, db.Conn, boil.Infer()); err != nil {
+		log.Fatalf("insert calendar entry: %v", err)
+	}
+	fmt.Printf("Booked %s as a break (calendar %d); streaks will not count it as missed.\n", day.Format(DateYMD), entry.ID.Int64)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// dayoffSignal is one reason to rest; Points is 0 when it did not trip.
+type dayoffSignal struct {
+	Name   string
+	Detail string
+	Points int
+}
+
+type dayoffAssessment struct {
+	Signals      []dayoffSignal
+	TrainedToday bool
+}
+
+func (a dayoffAssessment) Points() int {
+	n := 0
+	for _, s := range a.Signals {
+		n += s.Points
+	}
+	return n
+}
+
+func (a dayoffAssessment) Recommend() bool { return a.Points() >= dayoffThreshold }
+
+// Note is the calendar text for a booked rest day.
+func (a dayoffAssessment) Note() string {
+	var hits []string
+	for _, s := range a.Signals {
+		if s.Points > 0 {
+			hits = append(hits, s.Name)
+		}
+	}
+	if len(hits) == 0 {
+		return "rest day"
+	}
+	return "rest day: " + strings.Join(hits, ", ")
+}
+
+// assessDayoff scores the feedback trend, minutes against the baseline, recent
+// reviews and the current run of consecutive training days (excused days end a run).
+func assessDayoff(ctx context.Context, exec boil.ContextExecutor, f sessionFilter, excused map[time.Time]bool, scale int64, now time.Time) (dayoffAssessment, error) {
+	day := dateOnly(now)
+	recentFrom := day.AddDate(0, 0, 1-dayoffRecent)
+	baseFrom := recentFrom.AddDate(0, 0, -dayoffBaseline)
+
+	window := func(from, to time.Time) (sessionAgg, error) {
+		w := f
+		w.From, w.To = from, to
+		aggs, err := aggregateSessions(ctx, exec, bucketAll, w)
+		if err != nil || len(aggs) == 0 {
+			return sessionAgg{}, err
+		}
+		return aggs[0], nil
+	}
+	recent, err := window(recentFrom, day)
+	if err != nil {
+		return dayoffAssessment{}, err
+	}
+	base, err := window(baseFrom, recentFrom.AddDate(0, 0, -1))
+	if err != nil {
+		return dayoffAssessment{}, err
+	}
+
+	days, err := aggregateSessions(ctx, exec, bucketDay, sessionFilter{Task: f.Task, Tag: f.Tag, To: day})
+	if err != nil {
+		return dayoffAssessment{}, err
+	}
+	trained := make(map[time.Time]bool, len(days))
+	var first time.Time
+	for _, d := range days {
+		if t, err := time.Parse(DateYMD, d.Bucket); err == nil {
+			trained[t] = true
+			if first.IsZero() || t.Before(first) {
+				first = t
+			}
+		}
+	}
+
+	var a dayoffAssessment
+
+	// feedback: a drop of a tenth of the scale, or a recent mean in the bottom 40%
+	fb := dayoffSignal{Name: "falling feedback"}
+	switch {
+	case !recent.Feedback.Valid:
+		fb.Detail = "feedback: no scored sessions recently"
+	case base.Feedback.Valid:
+		delta := recent.Feedback.Float64 - base.Feedback.Float64
+		fb.Detail = fmt.Sprintf("feedback %.1f vs %.1f baseline (%+.1f)", recent.Feedback.Float64, base.Feedback.Float64, delta)
+		if -delta >= float64(scale)/10 || recent.Feedback.Float64 < 0.4*float64(scale) {
+			fb.Points = 1
+		}
+	default:
+		fb.Detail = fmt.Sprintf("feedback %.1f (no baseline)", recent.Feedback.Float64)
+		if recent.Feedback.Float64 < 0.4*float64(scale) {
+			fb.Points = 1
+		}
+	}
+	a.Signals = append(a.Signals, fb)
+
+	// load: recent minutes against the baseline's weekly mean, counting only
+	// baseline days since the first session; under a week is no baseline
+	load := dayoffSignal{Name: "heavy load"}
+	span := dayoffBaseline
+	if !first.IsZero() && first.After(baseFrom) {
+		span = daysBetween(first, recentFrom)
+	}
+	weekly := 0.0
+	if span >= dayoffRecent {
+		weekly = float64(base.Total) * float64(dayoffRecent) / float64(span)
+	}
+	if weekly > 0 {
+		ratio := float64(recent.Total) / weekly
+		load.Detail = fmt.Sprintf("%d min vs %.0f usual (%+.0f%%)", recent.Total, weekly, (ratio-1)*100)
+		if ratio >= dayoffLoadRatio {
+			load.Points = 1
+		}
+	} else {
+		load.Detail = fmt.Sprintf("%d min (no baseline)", recent.Total)
+	}
+	a.Signals = append(a.Signals, load)
+
+	// reviews: half or more of the recent ones name blockers or make no commitment
+	mods := []qm.QueryMod{qm.Where("reviewed IS NOT NULL AND date(reviewed) >= ?",
+		day.AddDate(0, 0, 1-dayoffReviews).Format(DateYMD))}
+	if f.Task != 0 {
+		mods = append(mods, qm.Where("task = ?", f.Task))
+	}
+	reviews, err := models.Reviews(withTaskTag("task", f.Tag, mods...)...).All(ctx, exec)
+	if err != nil {
+		return dayoffAssessment{}, err
+	}
+	stuck := 0
+	for _, r := range reviews {
+		if strings.TrimSpace(r.Blockers.String) != "" || strings.TrimSpace(r.Commitment.String) == "" {
+			stuck++
+		}
+	}
+	rev := dayoffSignal{Name: "stuck reviews"}
+	if len(reviews) == 0 {
+		rev.Detail = fmt.Sprintf("reviews: none in the last %d days", dayoffReviews)
+	} else {
+		rev.Detail = fmt.Sprintf("%d of %d recent reviews name blockers or no commitment", stuck, len(reviews))
+		if 2*stuck >= len(reviews) {
+			rev.Points = 1
+		}
+	}
+	a.Signals = append(a.Signals, rev)
+
+	// run: consecutive days with a session up to today, or yesterday if today is still open
+	a.TrainedToday = trained[day]
+	cur := day
+	if !a.TrainedToday {
+		cur = cur.AddDate(0, 0, -1)
+	}
+	run := 0
+	for trained[cur] && !excused[cur] {
+		run++
+		cur = cur.AddDate(0, 0, -1)
+	}
+	streak := dayoffSignal{Name: "no rest", Detail: fmt.Sprintf("%s in a row without rest", plural(run, "training day"))}
+	switch {
+	case run >= 2*dayoffRunDays:
+		streak.Points = 2
+	case run >= dayoffRunDays:
+		streak.Points = 1
+	}
+	a.Signals = append(a.Signals, streak)
+
+	return a, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
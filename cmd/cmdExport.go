@@ -51,18 +51,21 @@ var exportCmd = &cobra.Command{
 
 var (
 	exportAll bool
+	exportTag string
 )
 
 func init() {
 	rootCmd.AddCommand(exportCmd)
 	exportCmd.Flags().BoolVar(&exportAll, "all", false, "Export all tables")
+	exportCmd.Flags().StringVar(&exportTag, "tag", "", "Only export rows belonging to tasks with this tag")
+	_ = exportCmd.RegisterFlagCompletionFunc("tag", completeTagNames)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func runExport(cmd *cobra.Command, args []string) {
 	if exportAll {
-		args = []string{"tasks", "sessions", "milestones", "reviews", "coach", "calendar"}
+		args = []string{"tasks", "tags", "sessions", "milestones", "reviews", "coach", "calendar"}
 	}
 	if len(args) == 0 {
 		horus.CheckErr(cmd.Help())
@@ -75,13 +78,13 @@ func runExport(cmd *cobra.Command, args []string) {
 	for _, table := range args {
 		switch table {
 
-		// tasks: target/start are nullable datetimes; tag/description nullable text
+		// tasks: target/start are nullable datetimes; description/schedule nullable text; goal nullable int; tags comma-joined
 		case "tasks":
 			horus.CheckErr(exportTable(
 				ctx, exec,
 				"tasks.csv",
-				[]string{"id", "name", "tag", "description", "target", "start", "archived"},
-				models.Tasks(qm.OrderBy("id ASC")).All,
+				[]string{"id", "name", "tags", "description", "target", "start", "goal", "schedule", "archived"},
+				models.Tasks(withTaskTag("id", exportTag, qm.OrderBy("id ASC"), qm.Load(models.TaskRels.Tags))...).All,
 				func(t *models.Task) []string {
 					target, start := "", ""
 					if t.Target.Valid {
@@ -90,25 +93,46 @@ func runExport(cmd *cobra.Command, args []string) {
 					if t.Start.Valid {
 						start = t.Start.Time.Format(time.RFC3339)
 					}
+					goal := ""
+					if t.Goal.Valid {
+						goal = strconv.FormatInt(t.Goal.Int64, 10)
+					}
 					return []string{
 						strconv.FormatInt(t.ID.Int64, 10),
 						t.Name,
-						t.Tag.String,
+						taskTagsString(t),
 						t.Description.String,
 						target,
 						start,
+						goal,
+						t.Schedule.String,
 						strconv.FormatBool(t.Archived.Bool),
 					}
 				},
 			))
 
+		// tags: name required
+		case "tags":
+			horus.CheckErr(exportTable(
+				ctx, exec,
+				"tags.csv",
+				[]string{"id", "name"},
+				models.Tags(qm.OrderBy("id ASC")).All,
+				func(t *models.Tag) []string {
+					return []string{
+						strconv.FormatInt(t.ID.Int64, 10),
+						t.Name,
+					}
+				},
+			))
+
 		// sessions: class nullable text, date nullable, mins/feedback nullable ints, notes nullable text
 		case "sessions":
 			horus.CheckErr(exportTable(
 				ctx, exec,
 				"sessions.csv",
 				[]string{"id", "task", "class", "date", "mins", "feedback", "notes"},
-				models.Sessions(qm.OrderBy("id ASC")).All,
+				models.Sessions(withTaskTag("task", exportTag, qm.OrderBy("id ASC"))...).All,
 				func(s *models.Session) []string {
 					date := ""
 					if s.Date.Valid {
@@ -134,15 +158,18 @@ func runExport(cmd *cobra.Command, args []string) {
 				},
 			))
 
-		// milestones: done nullable date, value nullable int, type/message nullable text
+		// milestones: due/done nullable date, value nullable int, type/message nullable text
 		case "milestones":
 			horus.CheckErr(exportTable(
 				ctx, exec,
 				"milestones.csv",
-				[]string{"id", "task", "type", "value", "done", "message"},
-				models.Milestones(qm.OrderBy("id ASC")).All,
+				[]string{"id", "task", "type", "kind", "value", "due", "done", "message"},
+				models.Milestones(withTaskTag("task", exportTag, qm.OrderBy("id ASC"))...).All,
 				func(m *models.Milestone) []string {
-					done := ""
+					due, done := "", ""
+					if m.Due.Valid {
+						due = m.Due.Time.Format(DateYMD)
+					}
 					if m.Done.Valid {
 						done = m.Done.Time.Format(DateYMD)
 					}
@@ -154,20 +181,22 @@ func runExport(cmd *cobra.Command, args []string) {
 						strconv.FormatInt(m.ID.Int64, 10),
 						strconv.FormatInt(m.Task, 10),
 						m.Type.String,
+						m.Kind,
 						val,
+						due,
 						done,
 						m.Message.String,
 					}
 				},
 			))
 
-		// reviews: week nullable int, summary nullable text
+		// reviews: week nullable int, summary and answers nullable text, reviewed nullable date
 		case "reviews":
 			horus.CheckErr(exportTable(
 				ctx, exec,
 				"reviews.csv",
-				[]string{"id", "task", "week", "summary"},
-				models.Reviews(qm.OrderBy("id ASC")).All,
+				[]string{"id", "task", "week", "summary", "went_well", "blockers", "commitment", "reviewed"},
+				models.Reviews(withTaskTag("task", exportTag, qm.OrderBy("id ASC"))...).All,
 				func(r *models.Review) []string {
 					wk := ""
 					if r.Week.Valid {
@@ -178,16 +207,20 @@ func runExport(cmd *cobra.Command, args []string) {
 						strconv.FormatInt(r.Task, 10),
 						wk,
 						r.Summary.String,
+						r.WentWell.String,
+						r.Blockers.String,
+						r.Commitment.String,
+						OptTimeInitial(r.Reviewed, DateYMD),
 					}
 				},
 			))
 
-		// coach: date nullable
+		// coach: date and task nullable
 		case "coach":
 			horus.CheckErr(exportTable(
 				ctx, exec,
 				"coach.csv",
-				[]string{"id", "trigger", "content", "date"},
+				[]string{"id", "task", "trigger", "content", "cooldown", "date"},
 				models.Coaches(qm.OrderBy("id ASC")).All,
 				func(c *models.Coach) []string {
 					date := ""
@@ -196,19 +229,21 @@ func runExport(cmd *cobra.Command, args []string) {
 					}
 					return []string{
 						strconv.FormatInt(c.ID.Int64, 10),
+						OptInt64Initial(c.Task),
 						c.Trigger,
 						c.Content,
+						strconv.FormatInt(c.Cooldown, 10),
 						date,
 					}
 				},
 			))
 
-		// calendar: date nullable, note required
+		// calendar: date nullable, note and kind required
 		case "calendar":
 			horus.CheckErr(exportTable(
 				ctx, exec,
 				"calendar.csv",
-				[]string{"id", "date", "note"},
+				[]string{"id", "date", "kind", "note"},
 				models.Calendars(qm.OrderBy("id ASC")).All,
 				func(c *models.Calendar) []string {
 					date := ""
@@ -218,6 +253,7 @@ func runExport(cmd *cobra.Command, args []string) {
 					return []string{
 						strconv.FormatInt(c.ID.Int64, 10),
 						date,
+						c.Kind,
 						c.Note,
 					}
 				},
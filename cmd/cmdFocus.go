@@ -0,0 +1,279 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Sisu/db"
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var focusCmd = &cobra.Command{
+	Use:               "focus <task>",
+	Short:             "Pomodoro timer that logs each completed work interval",
+	Long:              helpFocus,
+	Example:           exampleFocus,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTaskArg,
+	PersistentPreRun:  dbPreRun,
+	PersistentPostRun: dbPostRun,
+	Run:               runFocus,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	flagFocusWork   time.Duration
+	flagFocusBreak  time.Duration
+	flagFocusRounds int
+	flagFocusClass  string
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(focusCmd)
+	focusCmd.Flags().DurationVar(&flagFocusWork, "work", 25*time.Minute, "length of a work interval")
+	focusCmd.Flags().DurationVar(&flagFocusBreak, "break", 5*time.Minute, "length of a break")
+	focusCmd.Flags().IntVar(&flagFocusRounds, "rounds", 4, "number of work intervals")
+	focusCmd.Flags().StringVar(&flagFocusClass, "class", "pomodoro", "class recorded on logged sessions")
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runFocus(_ *cobra.Command, args []string) {
+	if flagFocusWork < time.Minute || flagFocusBreak < 0 || flagFocusRounds < 1 {
+		log.Fatalf("focus: --work must be >= 1m, --break >= 0, --rounds >= 1")
+	}
+
+	ctx := db.Ctx()
+	task, err := resolveTask(ctx, db.Conn, args[0])
+	if err != nil {
+		log.Fatalf("focus: %v", err)
+	}
+
+	m := newFocusModel(task, flagFocusWork, flagFocusBreak, flagFocusRounds)
+	final, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	if err != nil {
+		log.Fatalf("focus: %v", err)
+	}
+	fm := final.(focusModel)
+	if fm.err != nil {
+		log.Fatalf("log pomodoro: %v", fm.err)
+	}
+
+	if fm.aborted {
+		fmt.Println("Focus run aborted.")
+	}
+	if len(fm.logged) == 0 {
+		fmt.Println("No completed work intervals; nothing logged.")
+		return
+	}
+	fmt.Printf("Logged %d pomodoro session(s) for %s (%d min)\n",
+		len(fm.logged), task.Name, int64(len(fm.logged))*focusMins(flagFocusWork))
+	// after the micro-review, so its score counts toward feedback milestones
+	defer announceMilestones(ctx, db.Conn, task.ID.Int64)
+
+	// micro-review shared by every interval of the run
+	review := &models.Session{}
+	scale := settingInt(settingFeedbackScale)
+	RunFormWizard([]Field{
+		FOptInt(fmt.Sprintf("Score (1–%d, optional)", scale), "Feedback", "", WithValidate(VIntRange(1, scale))),
+		FOptString("Notes (optional)", "Notes", ""),
+	}, review)
+
+	if !review.Feedback.Valid && review.Notes.String == "" {
+		return
+	}
+	ids := make([]any, 0, len(fm.logged))
+	for _, id := range fm.logged {
+		ids = append(ids, id)
+	}
+	if _, err := models.Sessions(qm.WhereIn("id IN ?", ids...)).UpdateAll(ctx, db.Conn, models.M{
+		models.SessionColumns.Feedback: review.Feedback,
+		models.SessionColumns.Notes:    review.Notes,
+	}); err != nil {
+		log.Fatalf("save review: %v", err)
+	}
+	fmt.Println("Saved review")
+}
+
+// focusMins is the minutes recorded for one work interval.
+func focusMins(d time.Duration) int64 {
+	return int64(math.Round(d.Minutes()))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type focusPhase struct {
+	work  bool
+	round int
+	total time.Duration
+}
+
+type focusModel struct {
+	task    *models.Task
+	phases  []focusPhase
+	idx     int
+	left    time.Duration
+	last    time.Time
+	paused  bool
+	width   int
+	logged  []int64
+	aborted bool
+	err     error
+}
+
+type focusTickMsg time.Time
+
+func newFocusModel(task *models.Task, work, brk time.Duration, rounds int) focusModel {
+	var phases []focusPhase
+	for r := 1; r <= rounds; r++ {
+		phases = append(phases, focusPhase{work: true, round: r, total: work})
+		if r < rounds && brk > 0 {
+			phases = append(phases, focusPhase{work: false, round: r, total: brk})
+		}
+	}
+	return focusModel{
+		task:   task,
+		phases: phases,
+		left:   phases[0].total,
+		last:   time.Now(),
+		width:  60,
+	}
+}
+
+func focusTick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return focusTickMsg(t) })
+}
+
+// logFocusSession inserts one completed work interval.
+func logFocusSession(taskID int64, work time.Duration) (int64, error) {
+	s := &models.Session{
+		Task:  taskID,
+		Class: null.StringFrom(flagFocusClass),
+		Date:  null.TimeFrom(today()),
+		Mins:  null.Int64From(focusMins(work)),
+	}
+	err := s.Insert(db.Ctx(), db.Conn, boil.Infer())
+	return s.ID.Int64, err
+}
+
+func (m focusModel) Init() tea.Cmd { return focusTick() }
+
+// advance moves to the next phase, logging the one just finished if it was work.
+// The insert runs in place, so m.logged holds every row written whenever the run ends.
+func (m focusModel) advance(completed bool) (tea.Model, tea.Cmd) {
+	if cur := m.phases[m.idx]; cur.work && completed {
+		id, err := logFocusSession(m.task.ID.Int64, cur.total)
+		if err != nil {
+			m.err = err
+			return m, tea.Quit
+		}
+		m.logged = append(m.logged, id)
+	}
+	m.idx++
+	if m.idx >= len(m.phases) {
+		return m, tea.Quit
+	}
+	m.left = m.phases[m.idx].total
+	m.last = time.Now()
+	return m, nil
+}
+
+func (m focusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+
+	case focusTickMsg:
+		now := time.Time(msg)
+		if m.idx >= len(m.phases) {
+			return m, nil
+		}
+		if !m.paused {
+			m.left -= now.Sub(m.last)
+		}
+		m.last = now
+		if m.left <= 0 {
+			mm, cmd := m.advance(true)
+			return mm, tea.Batch(cmd, focusTick())
+		}
+		return m, focusTick()
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			m.aborted = true
+			return m, tea.Quit
+		case " ", "p":
+			m.paused = !m.paused
+			m.last = time.Now()
+			return m, nil
+		case "s":
+			return m.advance(false)
+		}
+	}
+	return m, nil
+}
+
+func (m focusModel) View() string {
+	if m.idx >= len(m.phases) {
+		return ""
+	}
+	ph := m.phases[m.idx]
+	rounds := m.phases[len(m.phases)-1].round
+
+	label := "WORK"
+	if !ph.work {
+		label = "BREAK"
+	}
+	if m.paused {
+		label += " (paused)"
+	}
+
+	barWidth := m.width - 4
+	if barWidth > 80 {
+		barWidth = 80
+	}
+	frac := 1 - float64(m.left)/float64(ph.total)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n  FOCUS · %s · round %d/%d · logged %d\n\n", m.task.Name, ph.round, rounds, len(m.logged))
+	fmt.Fprintf(&b, "  %s  %s\n\n", label, formatClock(m.left.Round(time.Second)))
+	fmt.Fprintf(&b, "  %s\n\n", renderBar(frac, barWidth))
+	b.WriteString("  (space pause/resume, s skip, q abort)\n")
+	return b.String()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,199 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Sisu/db"
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var forecastCmd = &cobra.Command{
+	Use:               "forecast [task]",
+	Short:             "Project cumulative minutes to the target date and the odds of reaching the goal",
+	Long:              helpForecast,
+	Example:           exampleForecast,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTaskArg,
+	PersistentPreRun:  dbPreRun,
+	PersistentPostRun: dbPostRun,
+	Run:               runForecast,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	flagForecastTag    string
+	flagForecastHeight int
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(forecastCmd)
+
+	forecastCmd.Flags().StringVar(&flagForecastTag, "tag", "", "without a task, only forecast tasks with this tag")
+	forecastCmd.Flags().IntVar(&flagForecastHeight, "height", 12, "chart height in rows")
+	_ = forecastCmd.RegisterFlagCompletionFunc("tag", completeTagNames)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runForecast(_ *cobra.Command, args []string) {
+	ctx := db.Ctx()
+	now := time.Now()
+
+	if len(args) == 1 {
+		task, err := resolveTask(ctx, db.Conn, args[0])
+		if err != nil {
+			log.Fatalf("resolve task: %v", err)
+		}
+		f, err := newForecast(ctx, db.Conn, task, now)
+		if err != nil {
+			log.Fatalf("forecast %s: %v", task.Name, err)
+		}
+		fmt.Print(f.report(terminalWidth(), max(flagForecastHeight, 4), colorEnabled()))
+		return
+	}
+
+	tasks, err := models.Tasks(withTaskTag("id", flagForecastTag,
+		qm.Where("coalesce(archived, 0) = 0 AND target IS NOT NULL"),
+		qm.OrderBy("id ASC"),
+	)...).All(ctx, db.Conn)
+	if err != nil {
+		log.Fatalf("list tasks: %v", err)
+	}
+	if len(tasks) == 0 {
+		fmt.Println("No active tasks with a target date.")
+		return
+	}
+
+	rows := make([][]string, 0, len(tasks))
+	for _, t := range tasks {
+		f, err := newForecast(ctx, db.Conn, t, now)
+		if errors.Is(err, errNoForecast) {
+			rows = append(rows, []string{t.Name, fmt.Sprintf("%.0f", f.Done), forecastGoal(f), f.Target.Format(DateYMD), "-", "-", "-", "-"})
+			continue
+		}
+		if err != nil {
+			log.Fatalf("forecast %s: %v", t.Name, err)
+		}
+		chance, needed := "-", "-"
+		if f.Goal > 0 {
+			chance = fmt.Sprintf("%.0f%%", 100*f.Probability())
+			needed = fmt.Sprintf("%.0f", f.Needed())
+		}
+		rows = append(rows, []string{
+			t.Name,
+			fmt.Sprintf("%.0f", f.Done),
+			forecastGoal(f),
+			f.Target.Format(DateYMD),
+			fmt.Sprintf("%.0f", f.Projected(f.Linear)),
+			fmt.Sprintf("%.0f", f.Projected(f.Mean)),
+			chance,
+			needed,
+		})
+	}
+	fmt.Println(RenderTable([]string{"task", "done", "goal", "target", "linear", "ewma", "chance", "min/day"}, rows))
+}
+
+func forecastGoal(f *forecast) string {
+	if f.Goal == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f", f.Goal)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// report is the single-task view: both fits, the goal odds and the projection chart.
+func (f *forecast) report(width, height int, color bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %.0f min since %s, %s to the target (%s)\n\n",
+		f.Task.Name, f.Done, f.Start.Format(DateYMD), plural(f.DaysLeft(), "day"), f.Target.Format(DateYMD))
+
+	reach := func(rate float64) string {
+		if f.Goal == 0 {
+			return "-"
+		}
+		d := f.Reach(rate)
+		switch {
+		case d.IsZero():
+			return "never at this pace"
+		case d.After(f.Target):
+			return fmt.Sprintf("%s (%s late)", d.Format(DateYMD), plural(daysBetween(f.Target, d), "day"))
+		}
+		return d.Format(DateYMD)
+	}
+	fmt.Fprintf(&b, "  %-7s %-14s %-14s %s\n", "model", "pace", "at target", "goal reached")
+	fmt.Fprintf(&b, "  %-7s %-14s %-14s %s\n", "linear", fmt.Sprintf("%.1f min/day", f.Linear), fmt.Sprintf("%.0f min", f.Projected(f.Linear)), reach(f.Linear))
+	fmt.Fprintf(&b, "  %-7s %-14s %-14s %s\n", "ewma", fmt.Sprintf("%.1f min/day", f.Mean), fmt.Sprintf("%.0f min", f.Projected(f.Mean)), reach(f.Mean))
+	fmt.Fprintln(&b)
+
+	if f.Goal == 0 {
+		fmt.Fprintf(&b, "No goal set; add one with `sisu task edit %d --goal <minutes>`.\n", f.Task.ID.Int64)
+	} else {
+		fmt.Fprintf(&b, "Chance of %.0f min by %s: %.0f%%\n", f.Goal, f.Target.Format(DateYMD), 100*f.Probability())
+		switch {
+		case f.Remaining() == 0:
+			fmt.Fprintln(&b, "Goal already reached.")
+		case f.DaysLeft() == 0:
+			fmt.Fprintf(&b, "Target reached with %.0f min to go.\n", f.Remaining())
+		case f.Needed() > f.Mean:
+			fmt.Fprintf(&b, "To get back on track: %.0f min/day from tomorrow (now %.0f).\n", f.Needed(), f.Mean)
+		default:
+			fmt.Fprintf(&b, "On track: %.0f min/day from tomorrow is enough (now %.0f).\n", f.Needed(), f.Mean)
+		}
+	}
+	fmt.Fprintln(&b)
+
+	actual, linear, ewma := f.Curves()
+	series := []chartSeries{
+		{Vals: linear, Marker: '·', Color: ansiCyan},
+		{Vals: ewma, Marker: '∘', Color: ansiYellow},
+		{Vals: actual, Marker: '•', Color: ansiGreen},
+	}
+	if f.Goal > 0 {
+		goal := make([]float64, len(actual))
+		for i := range goal {
+			goal[i] = f.Goal
+		}
+		series = append([]chartSeries{{Vals: goal, Marker: '─'}}, series...)
+	}
+	format := func(v float64) string { return fmt.Sprintf("%.0f", v) }
+	b.WriteString(markerChart(series, f.Start.Format(DateYMD), f.Target.Format(DateYMD), width, height, color, format))
+	legend := "• logged  · linear  ∘ ewma"
+	if f.Goal > 0 {
+		legend += "  ─ goal"
+	}
+	fmt.Fprintln(&b, legend)
+	return b.String()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
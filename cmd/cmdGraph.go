@@ -0,0 +1,222 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Sisu/db"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var graphCmd = &cobra.Command{
+	Use:               "graph <minutes|feedback|sessions>",
+	Short:             "Chart minutes, feedback or sessions over time",
+	Long:              helpGraph,
+	Example:           exampleGraph,
+	Args:              cobra.ExactArgs(1),
+	ValidArgs:         graphMetrics,
+	PersistentPreRun:  dbPreRun,
+	PersistentPostRun: dbPostRun,
+	Run:               runGraph,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	flagGraphTask  string
+	flagGraphTag   string
+	flagGraphBy    string
+	flagGraphLast  string
+	flagGraphStyle string
+)
+
+var (
+	graphMetrics = []string{"minutes", "feedback", "sessions"}
+	graphStyles  = []string{"bar", "line", "spark"}
+)
+
+const graphLineHeight = 10
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+
+	graphCmd.Flags().StringVar(&flagGraphTask, "task", "", "only chart this task (ID or name)")
+	graphCmd.Flags().StringVar(&flagGraphTag, "tag", "", "only chart tasks with this tag")
+	graphCmd.Flags().StringVar(&flagGraphBy, "by", "week", "bucket size: day|week|month")
+	graphCmd.Flags().StringVar(&flagGraphLast, "last", "12w", "how far back: a bucket count or a span such as 30d, 12w, 6m")
+	graphCmd.Flags().StringVar(&flagGraphStyle, "style", "bar", "chart style: bar|line|spark")
+
+	_ = graphCmd.RegisterFlagCompletionFunc("task", completeTaskArg)
+	_ = graphCmd.RegisterFlagCompletionFunc("tag", completeTagNames)
+	_ = graphCmd.RegisterFlagCompletionFunc("by", cobra.FixedCompletions([]string{"day", "week", "month"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = graphCmd.RegisterFlagCompletionFunc("style", cobra.FixedCompletions(graphStyles, cobra.ShellCompDirectiveNoFileComp))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runGraph(_ *cobra.Command, args []string) {
+	ctx := db.Ctx()
+	metric := args[0]
+
+	switch metric {
+	case "minutes", "feedback", "sessions":
+	default:
+		log.Fatalf("invalid metric %q (use %s)", metric, strings.Join(graphMetrics, ", "))
+	}
+	switch flagGraphBy {
+	case "day", "week", "month":
+	default:
+		log.Fatalf("invalid --by %q (use day, week or month)", flagGraphBy)
+	}
+	n, err := parseBucketCount(flagGraphLast, flagGraphBy)
+	if err != nil {
+		log.Fatalf("parse --last: %v", err)
+	}
+
+	f := sessionFilter{Tag: flagGraphTag}
+	scope := "all tasks"
+	if flagGraphTask != "" {
+		task, err := resolveTask(ctx, db.Conn, flagGraphTask)
+		if err != nil {
+			log.Fatalf("resolve task: %v", err)
+		}
+		f.Task, scope = task.ID.Int64, task.Name
+	} else if flagGraphTag != "" {
+		scope = "tag " + flagGraphTag
+	}
+
+	weekStart := settingWeekday(settingWeekStart)
+	starts := bucketStarts(flagGraphBy, n, weekStart, time.Now())
+	f.From, f.To = starts[0], today()
+
+	aggs, err := aggregateSessions(ctx, db.Conn, timeBucket(flagGraphBy, weekStart), f)
+	if err != nil {
+		log.Fatalf("aggregate sessions: %v", err)
+	}
+	byKey := make(map[string]sessionAgg, len(aggs))
+	for _, a := range aggs {
+		byKey[a.Bucket] = a
+	}
+
+	labels := make([]string, n)
+	vals := make([]float64, n)
+	for i, s := range starts {
+		labels[i] = bucketKey(flagGraphBy, s)
+		vals[i] = graphValue(metric, byKey[labels[i]])
+	}
+
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', 0, 64) }
+	if metric == "feedback" {
+		format = func(v float64) string { return strconv.FormatFloat(v, 'f', 1, 64) }
+	}
+
+	color := colorEnabled()
+	width := terminalWidth()
+	fmt.Printf("%s per %s, last %d (%s)\n\n", metric, flagGraphBy, n, scope)
+
+	switch flagGraphStyle {
+	case "bar":
+		fmt.Print(barChart(labels, vals, width, color, format))
+	case "line":
+		fmt.Print(lineChart(vals, labels[0], labels[n-1], width, graphLineHeight, color, format))
+	case "spark":
+		spark := sparkline(vals)
+		if color {
+			spark = paint(ansiGreen, spark)
+		}
+		fmt.Printf("%s  %s\n", labels[0], spark)
+		fmt.Printf("%s  %s\n", strings.Repeat(" ", len(labels[0])), graphSummary(vals, format))
+	default:
+		log.Fatalf("invalid --style %q (use %s)", flagGraphStyle, strings.Join(graphStyles, ", "))
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// graphValue picks the metric from a bucket; feedback is NaN when no session was scored.
+func graphValue(metric string, a sessionAgg) float64 {
+	switch metric {
+	case "minutes":
+		return float64(a.Total)
+	case "sessions":
+		return float64(a.Sessions)
+	case "feedback":
+		if a.Feedback.Valid {
+			return a.Feedback.Float64
+		}
+		return math.NaN()
+	}
+	return 0
+}
+
+func graphSummary(vals []float64, format func(float64) string) string {
+	lo, hi, ok := seriesRange(vals)
+	if !ok {
+		return "no data"
+	}
+	last := "-"
+	if v := vals[len(vals)-1]; !math.IsNaN(v) {
+		last = format(v)
+	}
+	return fmt.Sprintf("min %s  max %s  last %s", format(lo), format(hi), last)
+}
+
+// parseBucketCount turns --last into a number of buckets: a bare count, or a
+// span in days, weeks or months ("30d", "12w", "6m") rounded up to whole buckets.
+func parseBucketCount(s, by string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 1 || n > 1000 {
+			return 0, fmt.Errorf("bucket count %d out of range 1..1000", n)
+		}
+		return n, nil
+	}
+	if len(s) < 2 {
+		return 0, fmt.Errorf("invalid span %q", s)
+	}
+
+	unitDays := map[byte]int{'d': 1, 'w': 7, 'm': 30}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	per, ok := unitDays[s[len(s)-1]]
+	if err != nil || !ok || n < 1 {
+		return 0, fmt.Errorf("invalid span %q (use 30d, 12w, 6m or a count)", s)
+	}
+	byDays := map[string]int{"day": 1, "week": 7, "month": 30}[by]
+	if byDays == per {
+		return n, nil
+	}
+	count := (n*per + byDays - 1) / byDays
+	if count > 1000 {
+		return 0, fmt.Errorf("span %q is too long for --by %s", s, by)
+	}
+	return count, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,352 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Sisu/db"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var heatmapCmd = &cobra.Command{
+	Use:               "heatmap",
+	Short:             "Yearly contribution heatmap of session minutes",
+	Long:              helpHeatmap,
+	Example:           exampleHeatmap,
+	Args:              cobra.NoArgs,
+	PersistentPreRun:  dbPreRun,
+	PersistentPostRun: dbPostRun,
+	Run:               runHeatmap,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	flagHeatmapTask string
+	flagHeatmapTag  string
+	flagHeatmapYear int
+	flagHeatmapSVG  string
+)
+
+// GitHub's contribution palette, indexed by heat level.
+var heatSVGPalette = [heatLevels]string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(heatmapCmd)
+
+	heatmapCmd.Flags().StringVar(&flagHeatmapTask, "task", "", "only count this task (ID or name)")
+	heatmapCmd.Flags().StringVar(&flagHeatmapTag, "tag", "", "only count tasks with this tag")
+	heatmapCmd.Flags().IntVar(&flagHeatmapYear, "year", 0, "calendar year to draw (default the last 53 weeks)")
+	heatmapCmd.Flags().StringVar(&flagHeatmapSVG, "svg", "", "also write the heatmap as a standalone SVG file")
+
+	_ = heatmapCmd.RegisterFlagCompletionFunc("task", completeTaskArg)
+	_ = heatmapCmd.RegisterFlagCompletionFunc("tag", completeTagNames)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runHeatmap(_ *cobra.Command, _ []string) {
+	ctx := db.Ctx()
+	weekStart := settingWeekday(settingWeekStart)
+
+	f := sessionFilter{Tag: flagHeatmapTag}
+	title := "all tasks"
+	if flagHeatmapTask != "" {
+		task, err := resolveTask(ctx, db.Conn, flagHeatmapTask)
+		if err != nil {
+			log.Fatalf("resolve task: %v", err)
+		}
+		f.Task, title = task.ID.Int64, task.Name
+	} else if flagHeatmapTag != "" {
+		title = "tag " + flagHeatmapTag
+	}
+
+	if flagHeatmapYear != 0 {
+		if flagHeatmapYear < 1900 || flagHeatmapYear > 9999 {
+			log.Fatalf("invalid --year %d", flagHeatmapYear)
+		}
+		f.From = time.Date(flagHeatmapYear, 1, 1, 0, 0, 0, 0, time.UTC)
+		f.To = time.Date(flagHeatmapYear, 12, 31, 0, 0, 0, 0, time.UTC)
+		title += fmt.Sprintf(", %d", flagHeatmapYear)
+	} else {
+		f.To = today()
+		f.From = startOfWeek(f.To, weekStart).AddDate(0, 0, -52*7)
+		title += ", last year"
+	}
+
+	aggs, err := aggregateSessions(ctx, db.Conn, bucketDay, f)
+	if err != nil {
+		log.Fatalf("aggregate sessions: %v", err)
+	}
+	hm := newHeatmap(f.From, f.To, weekStart, aggs)
+
+	fmt.Printf("%s\n\n", title)
+	fmt.Print(hm.render(terminalWidth(), colorEnabled()))
+
+	if flagHeatmapSVG != "" {
+		out, err := os.Create(flagHeatmapSVG)
+		if err != nil {
+			log.Fatalf("create %s: %v", flagHeatmapSVG, err)
+		}
+		defer out.Close()
+		if err := hm.writeSVG(out, title); err != nil {
+			log.Fatalf("write %s: %v", flagHeatmapSVG, err)
+		}
+		fmt.Printf("\nWrote %s\n", flagHeatmapSVG)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// heatmap is a 7-row grid of days, one column per week, from the week holding From to To.
+type heatmap struct {
+	Origin    time.Time // first cell: start of the week holding From
+	From, To  time.Time
+	Weeks     int
+	WeekStart time.Weekday
+	Mins      map[time.Time]int64
+	Cutoffs   []int64 // inclusive upper bounds of levels 1..heatLevels-2; above the last is the top level
+}
+
+func newHeatmap(from, to time.Time, weekStart time.Weekday, aggs []sessionAgg) *heatmap {
+	hm := &heatmap{
+		Origin:    startOfWeek(from, weekStart),
+		From:      from,
+		To:        to,
+		WeekStart: weekStart,
+		Mins:      make(map[time.Time]int64, len(aggs)),
+	}
+	hm.Weeks = daysBetween(hm.Origin, to)/7 + 1
+
+	var vals []int64
+	for _, a := range aggs {
+		d, err := time.Parse(DateYMD, a.Bucket)
+		if err != nil || a.Total <= 0 {
+			continue
+		}
+		hm.Mins[d] = a.Total
+		vals = append(vals, a.Total)
+	}
+	hm.Cutoffs = quantileCutoffs(vals, heatLevels-1)
+	return hm
+}
+
+// quantileCutoffs splits the values into n equal-count buckets and returns the n-1 inclusive upper bounds.
+func quantileCutoffs(vals []int64, n int) []int64 {
+	if len(vals) == 0 || n < 2 {
+		return nil
+	}
+	sorted := append([]int64(nil), vals...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	cuts := make([]int64, n-1)
+	for i := range cuts {
+		idx := (i+1)*len(sorted)/n - 1
+		cuts[i] = sorted[max(idx, 0)]
+	}
+	return cuts
+}
+
+// day returns the date of a cell and whether it falls inside From..To.
+func (hm *heatmap) day(week, row int) (time.Time, bool) {
+	d := hm.Origin.AddDate(0, 0, week*7+row)
+	return d, !d.Before(hm.From) && !d.After(hm.To)
+}
+
+// level buckets a day's minutes by the quantile cutoffs.
+func (hm *heatmap) level(mins int64) int {
+	if mins <= 0 {
+		return 0
+	}
+	for i, c := range hm.Cutoffs {
+		if mins <= c {
+			return i + 1
+		}
+	}
+	return heatLevels - 1
+}
+
+// monthLabels maps a week column to the month starting in it.
+func (hm *heatmap) monthLabels() map[int]string {
+	labels := map[int]string{}
+	for w := 0; w < hm.Weeks; w++ {
+		for r := 0; r < 7; r++ {
+			if d, ok := hm.day(w, r); ok && d.Day() == 1 {
+				labels[w] = d.Format("Jan")
+			}
+		}
+	}
+	return labels
+}
+
+// legend describes each level's minute range.
+func (hm *heatmap) legend() []string {
+	out := []string{"0"}
+	lo := int64(1)
+	for _, c := range hm.Cutoffs {
+		switch {
+		case c < lo:
+			out = append(out, "-")
+		case c == lo:
+			out = append(out, fmt.Sprint(c))
+		default:
+			out = append(out, fmt.Sprintf("%d-%d", lo, c))
+		}
+		lo = max(lo, c+1)
+	}
+	return append(out, fmt.Sprintf("%d+", lo))
+}
+
+func (hm *heatmap) total() (mins int64, days int) {
+	for _, v := range hm.Mins {
+		mins += v
+	}
+	return mins, len(hm.Mins)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// render draws two columns per week, dropping the oldest weeks that do not fit width;
+// without color, levels become block characters.
+func (hm *heatmap) render(width int, color bool) string {
+	var b strings.Builder
+	const gutter = 4
+	first := max(0, hm.Weeks-(width-gutter)/2)
+
+	months := hm.monthLabels()
+	header := []rune(strings.Repeat(" ", gutter+(hm.Weeks-first)*2+3))
+	for w, l := range months {
+		if w >= first {
+			copy(header[gutter+(w-first)*2:], []rune(l))
+		}
+	}
+	b.WriteString(strings.TrimRight(string(header), " ") + "\n")
+
+	cell := func(level int) string {
+		if color {
+			return heatBg(level, " ") + " "
+		}
+		return heatRunes[level] + " "
+	}
+
+	for r := 0; r < 7; r++ {
+		label := ""
+		if r%2 == 1 {
+			label = time.Weekday((int(hm.WeekStart) + r) % 7).String()[:3]
+		}
+		fmt.Fprintf(&b, "%-*s", gutter, label)
+		for w := first; w < hm.Weeks; w++ {
+			d, ok := hm.day(w, r)
+			if !ok {
+				b.WriteString("  ")
+				continue
+			}
+			b.WriteString(cell(hm.level(hm.Mins[d])))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n" + strings.Repeat(" ", gutter) + "Less ")
+	for l := 0; l < heatLevels; l++ {
+		b.WriteString(cell(l))
+	}
+	b.WriteString("More  (min: " + strings.Join(hm.legend(), " · ") + ")\n")
+
+	mins, days := hm.total()
+	fmt.Fprintf(&b, "%s%d min over %s\n", strings.Repeat(" ", gutter), mins, plural(days, "active day"))
+	return b.String()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// writeSVG writes the same grid, labels and legend as a standalone SVG.
+func (hm *heatmap) writeSVG(w io.Writer, title string) error {
+	const (
+		cell   = 11
+		gap    = 3
+		left   = 32
+		top    = 36
+		font   = `font-family="-apple-system,Segoe UI,Helvetica,Arial,sans-serif" font-size="10" fill="#57606a"`
+		legend = 28
+	)
+	step := cell + gap
+	width := left + hm.Weeks*step + gap
+	height := top + 7*step + legend
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="#ffffff"/>`+"\n")
+	fmt.Fprintf(&b, `<text x="%d" y="14" %s font-weight="bold">%s</text>`+"\n", left, font, html.EscapeString(title))
+
+	months := hm.monthLabels()
+	weeks := make([]int, 0, len(months))
+	for wk := range months {
+		weeks = append(weeks, wk)
+	}
+	sort.Ints(weeks)
+	for _, wk := range weeks {
+		fmt.Fprintf(&b, `<text x="%d" y="%d" %s>%s</text>`+"\n", left+wk*step, top-6, font, months[wk])
+	}
+	for r := 1; r < 7; r += 2 {
+		day := time.Weekday((int(hm.WeekStart) + r) % 7).String()[:3]
+		fmt.Fprintf(&b, `<text x="0" y="%d" %s>%s</text>`+"\n", top+r*step+cell-2, font, day)
+	}
+
+	for wk := 0; wk < hm.Weeks; wk++ {
+		for r := 0; r < 7; r++ {
+			d, ok := hm.day(wk, r)
+			if !ok {
+				continue
+			}
+			mins := hm.Mins[d]
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"><title>%s: %d min</title></rect>`+"\n",
+				left+wk*step, top+r*step, cell, cell, heatSVGPalette[hm.level(mins)], d.Format(DateYMD), mins)
+		}
+	}
+
+	ly := top + 7*step + 10
+	lx := width - gap - heatLevels*step - 60
+	fmt.Fprintf(&b, `<text x="%d" y="%d" %s>Less</text>`+"\n", lx-28, ly+cell-2, font)
+	for l := 0; l < heatLevels; l++ {
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"><title>%s min</title></rect>`+"\n",
+			lx+l*step, ly, cell, cell, heatSVGPalette[l], hm.legend()[l])
+	}
+	fmt.Fprintf(&b, `<text x="%d" y="%d" %s>More</text>`+"\n", lx+heatLevels*step+4, ly+cell-2, font)
+
+	mins, days := hm.total()
+	fmt.Fprintf(&b, `<text x="%d" y="%d" %s>%d min over %s</text>`+"\n", left, ly+cell-2, font, mins, plural(days, "active day"))
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
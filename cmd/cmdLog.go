@@ -0,0 +1,124 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Sisu/db"
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var logCmd = &cobra.Command{
+	Use:               "log <task> <duration> [notes...]",
+	Short:             "Log a finished session in one line",
+	Long:              helpLog,
+	Example:           exampleLog,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeTaskArg,
+	PersistentPreRun:  dbPreRun,
+	PersistentPostRun: dbPostRun,
+	Run:               runLog,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	flagLogOn    string
+	flagLogScore int64
+	flagLogClass string
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(logCmd)
+
+	logCmd.Flags().StringVar(&flagLogOn, "on", "today", "session date (YYYY-MM-DD, today, yesterday, -3d, last mon)")
+	logCmd.Flags().Int64Var(&flagLogScore, "score", 0, "feedback score (1..feedback_scale)")
+	logCmd.Flags().StringVar(&flagLogClass, "class", "", "session class (default from the session_class setting)")
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runLog(cmd *cobra.Command, args []string) {
+	ctx := db.Ctx()
+
+	task, err := resolveTask(ctx, db.Conn, args[0])
+	if err != nil {
+		log.Fatalf("resolve task: %v", err)
+	}
+
+	dur, err := parseDuration(args[1])
+	if err != nil {
+		log.Fatalf("parse duration: %v", err)
+	}
+	mins := int64(dur.Round(time.Minute) / time.Minute)
+	if mins < 1 {
+		mins = 1
+	}
+
+	on, err := parseRelativeDate(flagLogOn, time.Now())
+	if err != nil {
+		log.Fatalf("parse --on: %v", err)
+	}
+
+	sess := &models.Session{
+		Task: task.ID.Int64,
+		Date: null.TimeFrom(on),
+		Mins: null.Int64From(mins),
+	}
+
+	if cmd.Flags().Changed("score") {
+		scale := settingInt(settingFeedbackScale)
+		if flagLogScore < 1 || flagLogScore > scale {
+			log.Fatalf("--score must be between 1 and %d", scale)
+		}
+		sess.Feedback = null.Int64From(flagLogScore)
+	}
+
+	class := flagLogClass
+	if !cmd.Flags().Changed("class") {
+		class = settingString(settingSessionClass)
+	}
+	if class != "" {
+		sess.Class = null.StringFrom(class)
+	}
+
+	if notes := strings.TrimSpace(strings.Join(args[2:], " ")); notes != "" {
+		sess.Notes = null.StringFrom(notes)
+	}
+
+	if err := sess.Insert(ctx, db.Conn, boil.Infer()); err != nil {
+		log.Fatalf("insert session: %v", err)
+	}
+	fmt.Printf("Logged %dm of %s on %s (session %d)\n", mins, task.Name, on.Format(DateYMD), sess.ID.Int64)
+	announceMilestones(ctx, db.Conn, sess.Task)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -19,9 +19,13 @@ package cmd
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"strconv"
 
+	"github.com/golang-migrate/migrate/v4"
 	"github.com/spf13/cobra"
 
 	"github.com/DanielRivasMD/Sisu/db"
@@ -38,31 +42,163 @@ var migrateCmd = &cobra.Command{
 	Run: runMigrate,
 }
 
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show schema version, dirty state and pending migrations",
+	Args:  cobra.NoArgs,
+	Run:   runMigrateStatus,
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down [N]",
+	Short: "Roll back the last N migrations (default 1)",
+	Args:  cobra.MaximumNArgs(1),
+	Run:   runMigrateDown,
+}
+
+var migrateGotoCmd = &cobra.Command{
+	Use:   "goto <version>",
+	Short: "Migrate up or down to a specific version",
+	Args:  cobra.ExactArgs(1),
+	Run:   runMigrateGoto,
+}
+
+var migrateForceCmd = &cobra.Command{
+	Use:   "force <version>",
+	Short: "Set the schema version without running migrations (clears dirty state)",
+	Long:  helpMigrateForce,
+	Args:  cobra.ExactArgs(1),
+	Run:   runMigrateForce,
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func init() {
 	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.AddCommand(migrateStatusCmd, migrateDownCmd, migrateGotoCmd, migrateForceCmd)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // TODO: if successful, run `sqlboiler sqlite3`
 func runMigrate(cmd *cobra.Command, args []string) {
+	conn, m := openMigrate(true)
+	defer closeMigrate(conn)
 
-	// InitDB creates the file and runs migrations
-	conn, err := db.InitDB(dbPath)
-	if err != nil {
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
 		log.Fatalf("migrate failed: %v", err)
 	}
 
-	fmt.Printf("migrations applied; database at %s\n", dbPath)
+	fmt.Printf("migrations applied; database at %s\n", resolveDBPath())
+	printMigrateStatus(m)
+}
+
+func runMigrateStatus(cmd *cobra.Command, args []string) {
+	conn, m := openMigrate(false)
+	defer closeMigrate(conn)
+
+	printMigrateStatus(m)
+}
+
+func runMigrateDown(cmd *cobra.Command, args []string) {
+	steps := 1
+	if len(args) == 1 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			log.Fatalf("invalid step count %q: must be a positive integer", args[0])
+		}
+		steps = n
+	}
+
+	conn, m := openMigrate(true)
+	defer closeMigrate(conn)
+
+	if err := m.Steps(-steps); err != nil {
+		log.Fatalf("migrate down: %v", err)
+	}
+	fmt.Printf("rolled back %d migration(s)\n", steps)
+	printMigrateStatus(m)
+}
+
+func runMigrateGoto(cmd *cobra.Command, args []string) {
+	version, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		log.Fatalf("invalid version %q: %v", args[0], err)
+	}
+
+	conn, m := openMigrate(true)
+	defer closeMigrate(conn)
+
+	if err := m.Migrate(uint(version)); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		log.Fatalf("migrate goto %d: %v", version, err)
+	}
+	fmt.Printf("migrated to version %d\n", version)
+	printMigrateStatus(m)
+}
+
+func runMigrateForce(cmd *cobra.Command, args []string) {
+	version, err := strconv.Atoi(args[0])
+	if err != nil || version < -1 {
+		log.Fatalf("invalid version %q: must be an integer >= -1", args[0])
+	}
 
-	if conn != nil {
-		if err := conn.Close(); err != nil {
-			log.Printf("error closing DB: %v", err)
+	conn, m := openMigrate(false)
+	defer closeMigrate(conn)
+
+	if err := m.Force(version); err != nil {
+		log.Fatalf("migrate force %d: %v", version, err)
+	}
+	fmt.Printf("forced version %d\n", version)
+	printMigrateStatus(m)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// openMigrate opens the database without applying anything.
+// guard refuses to continue when the schema is newer than this binary.
+func openMigrate(guard bool) (*sql.DB, *migrate.Migrate) {
+	conn, err := db.Open(resolveDBPath())
+	if err != nil {
+		log.Fatalf("open DB: %v", err)
+	}
+	m, err := db.NewMigrate(conn)
+	if err != nil {
+		log.Fatalf("migrate failed: %v", err)
+	}
+	if guard {
+		if err := db.CheckSchema(m); err != nil {
+			log.Fatalf("migrate failed: %v", err)
 		}
 	}
+	return conn, m
+}
+
+func closeMigrate(conn *sql.DB) {
+	if err := conn.Close(); err != nil {
+		log.Printf("error closing DB: %v", err)
+	}
+}
+
+func printMigrateStatus(m *migrate.Migrate) {
+	st, err := db.Status(m)
+	if err != nil {
+		log.Fatalf("migrate status: %v", err)
+	}
 
+	fmt.Printf("database: %s\n", resolveDBPath())
+	fmt.Printf("version:  %d (latest known %d)\n", st.Version, st.Latest)
+	fmt.Printf("dirty:    %v\n", st.Dirty)
+	if st.Version > st.Latest {
+		fmt.Println("warning:  schema is newer than this binary; upgrade sisu")
+	}
+	if len(st.Pending) == 0 {
+		fmt.Println("pending:  none")
+		return
+	}
+	fmt.Println("pending:")
+	for _, f := range st.Pending {
+		fmt.Printf("  %s\n", f)
+	}
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
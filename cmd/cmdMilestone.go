@@ -23,8 +23,11 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"math"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
@@ -44,6 +47,8 @@ import (
 //   value INTEGER           → null.Int64 (optional)
 //   done DATE               → null.Time (optional)
 //   message TEXT            → null.String (optional)
+//   kind TEXT NOT NULL      → string (default "manual")
+//   due DATE                → null.Time (optional)
 
 var milestoneCmd = &cobra.Command{
 	Use:               "milestone",
@@ -56,24 +61,55 @@ var milestoneCmd = &cobra.Command{
 
 var milestoneAddCmd = &cobra.Command{
 	Use:   "add",
-	Short: "Interactive TUI to add a new milestone",
+	Short: "Add a new milestone (flags or interactive TUI)",
 	Run:   runMilestoneAdd,
 }
 
 var milestoneEditCmd = &cobra.Command{
 	Use:   "edit [id]",
-	Short: "Interactive TUI to edit a milestone",
+	Short: "Edit a milestone (flags or interactive TUI)",
 	Args:  cobra.ExactArgs(1),
 	Run:   runMilestoneEdit,
 }
 
+var milestoneCheckCmd = &cobra.Command{
+	Use:               "check [task]",
+	Short:             "Stamp open milestones the session history has reached",
+	Long:              helpMilestoneCheck,
+	Example:           exampleMilestoneCheck,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTaskArg,
+	Run:               runMilestoneCheck,
+}
+
+var milestoneProgressCmd = &cobra.Command{
+	Use:     "progress",
+	Short:   "Show progress bars and projected dates for open milestones",
+	Long:    helpMilestoneProgress,
+	Example: exampleMilestoneProgress,
+	Args:    cobra.NoArgs,
+	Run:     runMilestoneProgress,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var flagMilestoneProgressTask string
+
+// width of the progress bar column
+const milestoneBarWidth = 24
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func init() {
 	rootCmd.AddCommand(milestoneCmd)
-	milestoneCmd.AddCommand(milestoneAddCmd, milestoneEditCmd)
+	milestoneCmd.AddCommand(milestoneAddCmd, milestoneEditCmd, milestoneCheckCmd, milestoneProgressCmd)
+	BindFieldFlags(milestoneAddCmd, milestoneFields(&models.Milestone{}))
+	BindFieldFlags(milestoneEditCmd, milestoneFields(&models.Milestone{}))
+
+	milestoneProgressCmd.Flags().StringVar(&flagMilestoneProgressTask, "task", "", "only show this task (ID or name)")
+	_ = milestoneProgressCmd.RegisterFlagCompletionFunc("task", completeTaskArg)
 
-	RegisterCrudSubcommands(milestoneCmd, "sisu.db", CrudModel[*models.Milestone]{
+	RegisterCrudSubcommands(milestoneCmd, resolveDBPath, CrudModel[*models.Milestone]{
 		Singular: "milestone",
 
 		ListFn: func(ctx context.Context, conn *sql.DB) ([]*models.Milestone, error) {
@@ -89,15 +125,18 @@ func init() {
 			if m.Value.Valid {
 				val = strconv.FormatInt(m.Value.Int64, 10)
 			}
-			return m.ID.Int64, fmt.Sprintf("task=%d type=%s value=%s done=%s msg=%s",
-				m.Task, m.Type.String, val, done, m.Message.String)
+			return m.ID.Int64, fmt.Sprintf("task=%d type=%s kind=%s value=%s done=%s msg=%s",
+				m.Task, m.Type.String, m.Kind, val, done, m.Message.String)
 		},
 
-		TableHeaders: []string{"id", "task", "type", "value", "done", "message"},
+		TableHeaders: []string{"id", "task", "type", "kind", "value", "due", "done", "message"},
 		TableRow: func(m *models.Milestone) []string {
-			done := ""
+			due, done := "", ""
+			if m.Due.Valid {
+				due = m.Due.Time.Format(DateYMD)
+			}
 			if m.Done.Valid {
-				done = m.Done.Time.Format(time.RFC3339)
+				done = m.Done.Time.Format(DateYMD)
 			}
 			val := ""
 			if m.Value.Valid {
@@ -107,7 +146,9 @@ func init() {
 				strconv.FormatInt(m.ID.Int64, 10),
 				strconv.FormatInt(m.Task, 10),
 				m.Type.String,
+				m.Kind,
 				val,
+				due,
 				done,
 				m.Message.String,
 			}
@@ -126,18 +167,31 @@ func init() {
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func runMilestoneAdd(_ *cobra.Command, _ []string) {
-	m := &models.Milestone{}
-
-	fields := []Field{
-		FInt("Task ID", "Task", ""),
-		FOptString("Type (optional)", "Type", ""),
-		FOptInt("Value (optional)", "Value", ""),
-		FOptDate("Done date (YYYY-MM-DD, optional)", "Done", ""),
-		FOptString("Message (optional)", "Message", ""),
+func milestoneFields(m *models.Milestone) []Field {
+	kind := m.Kind
+	if kind == "" {
+		kind = milestoneManual
+	}
+	return []Field{
+		FInt("Task ID", "Task", IDInitial(m.Task)),
+		FOptString("Type (optional)", "Type", OptStringInitial(m.Type)),
+		FChoice(fmt.Sprintf("Kind (%s)", strings.Join(milestoneKinds, "/")), kind, milestoneKinds,
+			func(v string) { m.Kind = v },
+			WithName("kind"),
+		),
+		FOptInt("Value (target for the kind, optional)", "Value", OptInt64Initial(m.Value)),
+		FOptDate("Due date (YYYY-MM-DD, optional)", "Due", OptTimeInitial(m.Due, DateYMD)),
+		FOptDate("Done date (YYYY-MM-DD, optional)", "Done", OptTimeInitial(m.Done, DateYMD)),
+		FOptString("Message (optional)", "Message", OptStringInitial(m.Message)),
 	}
+}
 
-	RunFormWizard(fields, m)
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runMilestoneAdd(cmd *cobra.Command, _ []string) {
+	m := &models.Milestone{}
+
+	RunForm(cmd, milestoneFields(m), m)
 
 	if err := m.Insert(context.Background(), db.Conn, boil.Infer()); err != nil {
 		log.Fatalf("insert milestone: %v", err)
@@ -147,7 +201,7 @@ func runMilestoneAdd(_ *cobra.Command, _ []string) {
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func runMilestoneEdit(_ *cobra.Command, args []string) {
+func runMilestoneEdit(cmd *cobra.Command, args []string) {
 	idNum, err := strconv.ParseInt(args[0], 10, 64)
 	if err != nil {
 		log.Fatalf("invalid milestone ID %q: %v", args[0], err)
@@ -158,15 +212,7 @@ func runMilestoneEdit(_ *cobra.Command, args []string) {
 		log.Fatalf("find milestone %d: %v", idNum, err)
 	}
 
-	fields := []Field{
-		FInt("Task ID", "Task", strconv.FormatInt(m.Task, 10)),
-		FOptString("Type (optional)", "Type", m.Type.String),
-		FOptInt("Value (optional)", "Value", OptInt64Initial(m.Value)),
-		FOptDate("Done date (YYYY-MM-DD, optional)", "Done", OptTimeInitial(m.Done, DateYMD)),
-		FOptString("Message (optional)", "Message", m.Message.String),
-	}
-
-	RunFormWizard(fields, m)
+	RunEditForm(cmd, milestoneFields(m), m)
 
 	if _, err := m.Update(context.Background(), db.Conn, boil.Infer()); err != nil {
 		log.Fatalf("update milestone: %v", err)
@@ -175,3 +221,173 @@ func runMilestoneEdit(_ *cobra.Command, args []string) {
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runMilestoneCheck(_ *cobra.Command, args []string) {
+	ctx := db.Ctx()
+
+	var taskID int64
+	if len(args) == 1 {
+		task, err := resolveTask(ctx, db.Conn, args[0])
+		if err != nil {
+			log.Fatalf("resolve task: %v", err)
+		}
+		taskID = task.ID.Int64
+	}
+
+	reached, err := checkMilestones(ctx, db.Conn, taskID, time.Now())
+	if err != nil {
+		log.Fatalf("check milestones: %v", err)
+	}
+	if len(reached) == 0 {
+		fmt.Println("No new milestones reached.")
+		return
+	}
+	names, err := taskNames(ctx, db.Conn)
+	if err != nil {
+		log.Fatalf("list tasks: %v", err)
+	}
+	for _, m := range reached {
+		fmt.Println(milestoneReachedLine(m, names[m.Task]))
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runMilestoneProgress(_ *cobra.Command, _ []string) {
+	ctx := db.Ctx()
+	now := time.Now()
+
+	mods := []qm.QueryMod{qm.Where("coalesce(archived, 0) = 0"), qm.OrderBy("id ASC")}
+	if flagMilestoneProgressTask != "" {
+		task, err := resolveTask(ctx, db.Conn, flagMilestoneProgressTask)
+		if err != nil {
+			log.Fatalf("resolve task: %v", err)
+		}
+		mods = []qm.QueryMod{qm.Where("id = ?", task.ID.Int64)}
+	}
+	tasks, err := models.Tasks(mods...).All(ctx, db.Conn)
+	if err != nil {
+		log.Fatalf("list tasks: %v", err)
+	}
+
+	rules, err := defaultStreakRules(ctx, db.Conn)
+	if err != nil {
+		log.Fatalf("load streak rules: %v", err)
+	}
+
+	shown := 0
+	for _, t := range tasks {
+		open, err := models.Milestones(
+			qm.Where("task = ? AND done IS NULL AND kind != ? AND value IS NOT NULL", t.ID.Int64, milestoneManual),
+			qm.OrderBy("due IS NULL, due ASC, id ASC"),
+		).All(ctx, db.Conn)
+		if err != nil {
+			log.Fatalf("list milestones: %v", err)
+		}
+		if len(open) == 0 {
+			continue
+		}
+		h, err := loadTaskHistory(ctx, db.Conn, t, rules)
+		if err != nil {
+			log.Fatalf("load sessions for task %d: %v", t.ID.Int64, err)
+		}
+		// reached but not yet stamped by `milestone check` is no longer open
+		var progress []milestoneProgress
+		for _, m := range open {
+			if _, ok := h.reachedOn(m.Kind, float64(m.Value.Int64), dateOnly(now)); ok {
+				continue
+			}
+			progress = append(progress, h.projectMilestone(m, now))
+		}
+		if len(progress) == 0 {
+			continue
+		}
+
+		if shown > 0 {
+			fmt.Println()
+		}
+		shown++
+		fmt.Print(renderMilestoneProgress(t, progress, colorEnabled()))
+	}
+	if shown == 0 {
+		fmt.Println("No open milestones to track.")
+	}
+}
+
+// renderMilestoneProgress prints a task heading, one bar per milestone and a warning
+// for every projection that lands after the task target.
+func renderMilestoneProgress(t *models.Task, progress []milestoneProgress, color bool) string {
+	var b strings.Builder
+	heading := t.Name
+	if t.Target.Valid {
+		heading += fmt.Sprintf(" (target %s)", t.Target.Time.Format(DateYMD))
+	}
+	fmt.Fprintln(&b, heading)
+
+	rows := make([][]string, len(progress))
+	widths := make([]int, 4)
+	for i, p := range progress {
+		m := p.Milestone
+		label := m.Type.String
+		if label == "" {
+			label = m.Kind
+		}
+		current := fmt.Sprintf("%.0f", p.Current)
+		if m.Kind == milestoneFeedback {
+			current = fmt.Sprintf("%.1f", p.Current)
+		}
+		rows[i] = []string{
+			label,
+			fmt.Sprintf("%s of %s", current, milestoneUnit(m.Kind, p.Target)),
+			fmt.Sprintf("%3.0f%%", 100*p.Frac()),
+			milestonePace(m.Kind, p.Pace),
+		}
+		for j, c := range rows[i] {
+			widths[j] = max(widths[j], utf8.RuneCountInString(c))
+		}
+	}
+
+	var warnings []string
+	for i, p := range progress {
+		bar := renderBar(p.Frac(), milestoneBarWidth)
+		if color {
+			bar = paint(ansiGreen, bar)
+		}
+		eta := "stalled"
+		switch {
+		case !p.ETA.IsZero():
+			eta = "eta " + p.ETA.Format(DateYMD)
+		case math.IsNaN(p.Pace):
+			eta = "no projection"
+		}
+		fmt.Fprintf(&b, "  %-*s %s %-*s %*s  %-*s  %s\n",
+			widths[0], rows[i][0], bar, widths[1], rows[i][1], widths[2], rows[i][2],
+			widths[3], rows[i][3], eta)
+
+		if !p.ETA.IsZero() && t.Target.Valid && p.ETA.After(dateOnly(t.Target.Time)) {
+			warnings = append(warnings, fmt.Sprintf("  ! %s is projected for %s, %s after the target",
+				rows[i][0], p.ETA.Format(DateYMD), plural(daysBetween(dateOnly(t.Target.Time), p.ETA), "day")))
+		}
+	}
+	for _, w := range warnings {
+		fmt.Fprintln(&b, w)
+	}
+	return b.String()
+}
+
+// milestonePace formats a per-day pace as a weekly rate in the kind's unit.
+func milestonePace(kind string, perDay float64) string {
+	if math.IsNaN(perDay) {
+		return "-"
+	}
+	week := perDay * 7
+	switch kind {
+	case milestoneMinutes:
+		return fmt.Sprintf("%.0f min/wk", week)
+	case milestoneSessions:
+		return fmt.Sprintf("%.1f sessions/wk", week)
+	}
+	return fmt.Sprintf("%.1f days/wk", week)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,189 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var profileCmd = &cobra.Command{
+	Use:     "profile",
+	Short:   "Inspect task profiles (milestones, reviews, coach seeded by `task add`)",
+	Long:    helpProfile,
+	Example: exampleProfile,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available profiles",
+	Args:  cobra.NoArgs,
+	Run:   runProfileList,
+}
+
+var profileShowCmd = &cobra.Command{
+	Use:               "show <name>",
+	Short:             "Show the rows a profile seeds",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProfileNames,
+	Run:               runProfileShow,
+}
+
+var profileValidateCmd = &cobra.Command{
+	Use:   "validate [file.toml...]",
+	Short: "Validate profile files (every available profile when none given)",
+	Run:   runProfileValidate,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileListCmd, profileShowCmd, profileValidateCmd)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runProfileList(_ *cobra.Command, _ []string) {
+	ps, err := loadProfiles()
+	if err != nil {
+		log.Fatalf("load profiles: %v", err)
+	}
+
+	rows := make([][]string, 0, len(ps))
+	for _, name := range profileNames(ps) {
+		p := ps[name]
+		rows = append(rows, []string{
+			p.Name,
+			strconv.Itoa(len(p.Milestones)),
+			strconv.Itoa(len(p.Reviews)),
+			strconv.Itoa(len(p.Coach)),
+			p.Source,
+			p.Description,
+		})
+	}
+	fmt.Println(RenderTable([]string{"name", "milestones", "reviews", "coach", "source", "description"}, rows))
+	fmt.Printf("user profiles: %s\n", profileDir())
+}
+
+func runProfileShow(_ *cobra.Command, args []string) {
+	ps, err := loadProfiles()
+	if err != nil {
+		log.Fatalf("load profiles: %v", err)
+	}
+	p, ok := ps[args[0]]
+	if !ok {
+		log.Fatalf("unknown profile %q (available: %s)", args[0], strings.Join(profileNames(ps), ", "))
+	}
+
+	fmt.Printf("%s (%s)\n", p.Name, p.Source)
+	if p.Description != "" {
+		fmt.Println(p.Description)
+	}
+	fmt.Println()
+
+	milestones := make([][]string, 0, len(p.Milestones))
+	for _, m := range p.Milestones {
+		kind := m.Kind
+		if kind == "" {
+			kind = milestoneManual
+		}
+		milestones = append(milestones, []string{m.Type, kind, strconv.FormatInt(m.Value, 10), "+" + strconv.Itoa(m.Days), m.Message})
+	}
+	fmt.Println("milestones")
+	fmt.Println(RenderTable([]string{"type", "kind", "value", "days", "message"}, milestones))
+
+	reviews := make([][]string, 0, len(p.Reviews))
+	for _, r := range p.Reviews {
+		reviews = append(reviews, []string{strconv.FormatInt(r.Week, 10), r.Summary})
+	}
+	fmt.Println("reviews")
+	fmt.Println(RenderTable([]string{"week", "summary"}, reviews))
+
+	coach := make([][]string, 0, len(p.Coach))
+	for _, c := range p.Coach {
+		cooldown := "1"
+		if c.Cooldown != nil {
+			cooldown = strconv.FormatInt(*c.Cooldown, 10)
+		}
+		coach = append(coach, []string{c.Trigger, c.Content, cooldown})
+	}
+	fmt.Println("coach")
+	fmt.Println(RenderTable([]string{"trigger", "content", "cooldown"}, coach))
+}
+
+func runProfileValidate(_ *cobra.Command, args []string) {
+	var list []*Profile
+	if len(args) == 0 {
+		ps, err := loadProfiles()
+		if err != nil {
+			log.Fatalf("load profiles: %v", err)
+		}
+		for _, name := range profileNames(ps) {
+			list = append(list, ps[name])
+		}
+	} else {
+		for _, path := range args {
+			p, err := readProfileFile(path)
+			if err != nil {
+				log.Fatalf("read profile: %v", err)
+			}
+			list = append(list, p)
+		}
+	}
+
+	failed := false
+	for _, p := range list {
+		if err := p.Validate(); err != nil {
+			failed = true
+			fmt.Printf("✗ %s (%s)\n  %s\n", p.Name, p.Source, strings.ReplaceAll(err.Error(), "\n", "\n  "))
+			continue
+		}
+		fmt.Printf("✓ %s (%s)\n", p.Name, p.Source)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func completeProfileNames(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ps, err := loadProfiles()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	comps := make([]string, 0, len(ps))
+	for _, name := range profileNames(ps) {
+		if strings.HasPrefix(name, toComplete) {
+			comps = append(comps, name+"\t"+ps[name].Description)
+		}
+	}
+	return comps, cobra.ShellCompDirectiveNoFileComp
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -21,9 +21,12 @@ package cmd
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
@@ -38,30 +41,48 @@ import (
 
 var reviewCmd = &cobra.Command{
 	Use:   "review",
-	Short: "Manage review entries (list, rm via CLI; add, edit via TUI)",
+	Short: "Manage review entries (list, rm via CLI; add, edit via flags or TUI)",
 }
 
 var reviewAddCmd = &cobra.Command{
 	Use:   "add",
-	Short: "Interactive TUI to add a new review",
+	Short: "Add a new review (flags or interactive TUI)",
 	Run:   runReviewAdd,
 }
 
 var reviewEditCmd = &cobra.Command{
 	Use:   "edit [id]",
-	Short: "Interactive TUI to edit an existing review",
+	Short: "Edit an existing review (flags or interactive TUI)",
 	Args:  cobra.ExactArgs(1),
 	Run:   runReviewEdit,
 }
 
+var reviewStartCmd = &cobra.Command{
+	Use:               "start <task>",
+	Short:             "Guided review of the week that is due, with a stats digest",
+	Long:              helpReviewStart,
+	Example:           exampleReviewStart,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTaskArg,
+	Run:               runReviewStart,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var flagReviewStartWeek int64
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func init() {
 	// attach the review parent and TUI subcommands
 	rootCmd.AddCommand(reviewCmd)
-	reviewCmd.AddCommand(reviewAddCmd, reviewEditCmd)
+	reviewCmd.AddCommand(reviewAddCmd, reviewEditCmd, reviewStartCmd)
+	BindFieldFlags(reviewAddCmd, reviewAddFields())
+	BindFieldFlags(reviewEditCmd, reviewEditFields(&models.Review{}))
+	BindFieldFlags(reviewStartCmd, reviewAnswerFields(&models.Review{}))
+	reviewStartCmd.Flags().Int64Var(&flagReviewStartWeek, "week", 0, "review this task-relative week instead of the one due")
 
-	RegisterCrudSubcommands(reviewCmd, "sisu.db", CrudModel[*models.Review]{
+	RegisterCrudSubcommands(reviewCmd, resolveDBPath, CrudModel[*models.Review]{
 		Singular: "review",
 
 		ListFn: func(ctx context.Context, conn *sql.DB) ([]*models.Review, error) {
@@ -76,7 +97,7 @@ func init() {
 			return r.ID.Int64, fmt.Sprintf("task=%d week=%s summary=%s", r.Task, wk, r.Summary.String)
 		},
 
-		TableHeaders: []string{"id", "task", "week", "summary"},
+		TableHeaders: []string{"id", "task", "week", "reviewed", "summary", "commitment"},
 		TableRow: func(r *models.Review) []string {
 			wk := ""
 			if r.Week.Valid {
@@ -86,7 +107,9 @@ func init() {
 				strconv.FormatInt(r.ID.Int64, 10),
 				strconv.FormatInt(r.Task, 10),
 				wk,
+				OptTimeInitial(r.Reviewed, DateYMD),
 				r.Summary.String,
+				r.Commitment.String,
 			}
 		},
 
@@ -103,16 +126,59 @@ func init() {
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func runReviewAdd(_ *cobra.Command, _ []string) {
-	rev := &models.Review{}
+func reviewAddFields() []Field {
+	// capture Task for computing the default week
+	var taskPicked int64
 
-	fields := []Field{
-		FInt("Task ID", "Task", ""),
-		FOptInt("Week (optional)", "Week", ""),
+	return []Field{
+		FInt("Task ID", "Task", "",
+			WithAssign(func(h any, v any) {
+				AssignInt64("Task", h, v)
+				taskPicked = v.(int64)
+			}),
+		),
+		FOptInt("Week (optional) Default calculated from the task start", "Week", "",
+			WithParse(func(s string) (any, error) {
+				if strings.TrimSpace(s) != "" {
+					return ParseOptInt64(s)
+				}
+				task, err := models.FindTask(db.Ctx(), db.Conn, null.Int64From(taskPicked))
+				if err != nil {
+					return nil, fmt.Errorf("find task %d: %w", taskPicked, err)
+				}
+				if !task.Start.Valid {
+					return null.Int64{}, nil
+				}
+				return null.Int64From(taskWeek(task.Start.Time, time.Now(), settingWeekday(settingWeekStart))), nil
+			}),
+		),
 		FOptString("Summary (optional)", "Summary", ""),
 	}
+}
 
-	RunFormWizard(fields, rev)
+func reviewEditFields(rev *models.Review) []Field {
+	return append([]Field{
+		FInt("Task ID", "Task", IDInitial(rev.Task)),
+		FOptInt("Week (optional)", "Week", OptInt64Initial(rev.Week)),
+	}, reviewAnswerFields(rev)...)
+}
+
+// reviewAnswerFields are the reflection prompts of a review.
+func reviewAnswerFields(rev *models.Review) []Field {
+	return []Field{
+		FOptString("Summary (optional)", "Summary", OptStringInitial(rev.Summary)),
+		FOptString("What went well?", "WentWell", OptStringInitial(rev.WentWell)),
+		FOptString("What got in the way?", "Blockers", OptStringInitial(rev.Blockers)),
+		FOptString("What do you commit to next week?", "Commitment", OptStringInitial(rev.Commitment)),
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runReviewAdd(cmd *cobra.Command, _ []string) {
+	rev := &models.Review{}
+
+	RunForm(cmd, reviewAddFields(), rev)
 
 	if err := rev.Insert(context.Background(), db.Conn, boil.Infer()); err != nil {
 		log.Fatalf("insert review: %v", err)
@@ -122,7 +188,7 @@ func runReviewAdd(_ *cobra.Command, _ []string) {
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func runReviewEdit(_ *cobra.Command, args []string) {
+func runReviewEdit(cmd *cobra.Command, args []string) {
 	rawID := args[0]
 	idNum, err := strconv.ParseInt(rawID, 10, 64)
 	if err != nil {
@@ -134,18 +200,199 @@ func runReviewEdit(_ *cobra.Command, args []string) {
 		log.Fatalf("find review %d: %v", idNum, err)
 	}
 
-	fields := []Field{
-		FInt("Task ID", "Task", strconv.FormatInt(rev.Task, 10)),
-		FOptInt("Week (optional)", "Week", OptInt64Initial(rev.Week)),
-		FOptString("Summary (optional)", "Summary", rev.Summary.String),
-	}
-
-	RunFormWizard(fields, rev)
+	RunEditForm(cmd, reviewEditFields(rev), rev)
 
-	if _, err := rev.Update(context.Background(), db.Conn, boil.Whitelist("task", "week", "summary")); err != nil {
+	if _, err := rev.Update(context.Background(), db.Conn, boil.Whitelist("task", "week", "summary", "went_well", "blockers", "commitment")); err != nil {
 		log.Fatalf("update review: %v", err)
 	}
 	fmt.Printf("Updated review %d\n", rev.ID.Int64)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runReviewStart(cmd *cobra.Command, args []string) {
+	ctx := db.Ctx()
+	weekStart := settingWeekday(settingWeekStart)
+	now := time.Now()
+
+	task, err := resolveTask(ctx, db.Conn, args[0])
+	if err != nil {
+		log.Fatalf("resolve task: %v", err)
+	}
+	if !task.Start.Valid {
+		log.Fatalf("task %d has no start date; use review add", task.ID.Int64)
+	}
+
+	var rev *models.Review
+	if cmd.Flags().Changed("week") {
+		if flagReviewStartWeek < 1 {
+			log.Fatalf("--week must be >= 1")
+		}
+		rev, err = reviewForWeek(ctx, db.Conn, task.ID.Int64, flagReviewStartWeek)
+	} else {
+		rev, err = dueReview(ctx, db.Conn, task, weekStart, now)
+	}
+	if err != nil {
+		log.Fatalf("find due review: %v", err)
+	}
+	if rev == nil {
+		fmt.Printf("No review due for %s; use --week to revisit one.\n", task.Name)
+		return
+	}
+
+	// the reflection is the review: the wizard asks for it on a TTY, flags elsewhere
+	if !stdinIsTTY() && !cmd.Flags().Changed("went-well") && !cmd.Flags().Changed("blockers") && !cmd.Flags().Changed("commitment") {
+		log.Fatalf("answer at least one of --went-well, --blockers or --commitment")
+	}
+
+	week := rev.Week.Int64
+	from, to := taskWeekSpan(task.Start.Time, week, weekStart)
+	digest, err := reviewDigest(ctx, db.Conn, task.ID.Int64, from, to, now)
+	if err != nil {
+		log.Fatalf("review digest: %v", err)
+	}
+
+	fmt.Printf("%s, week %d (%s..%s)\n", task.Name, week, from.Format(DateYMD), to.Format(DateYMD))
+	fmt.Printf("  %s\n", digest)
+	prev, err := models.Reviews(
+		qm.Where("task = ? AND week < ? AND coalesce(commitment, '') != ''", task.ID.Int64, week),
+		qm.OrderBy("week DESC"),
+	).One(ctx, db.Conn)
+	if err == nil {
+		fmt.Printf("  last commitment (week %d): %s\n", prev.Week.Int64, prev.Commitment.String)
+	}
+	fmt.Println()
+
+	// the digest follows any label the profile seeded, e.g. "first: "
+	if !rev.Reviewed.Valid {
+		rev.Summary = null.StringFrom(rev.Summary.String + digest)
+	}
+	RunEditForm(cmd, reviewAnswerFields(rev), rev)
+	if rev.WentWell.String == "" && rev.Blockers.String == "" && rev.Commitment.String == "" {
+		fmt.Printf("Nothing answered; week %d stays pending\n", week)
+		return
+	}
+	rev.Reviewed = null.TimeFrom(today())
+
+	if rev.ID.Valid {
+		_, err = rev.Update(ctx, db.Conn, boil.Whitelist("summary", "went_well", "blockers", "commitment", "reviewed"))
+	} else {
+		err = rev.Insert(ctx, db.Conn, boil.Infer())
+	}
+	if err != nil {
+		log.Fatalf("save review: %v", err)
+	}
+	fmt.Printf("Saved review %d (week %d)\n", rev.ID.Int64, week)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// dueReview picks the review to do next: the earliest pending review seeded up to the
+// current task week, else last week or this week if either has not been reviewed.
+// It returns nil when nothing is due; new reviews are returned unsaved.
+func dueReview(ctx context.Context, exec boil.ContextExecutor, task *models.Task, weekStart time.Weekday, now time.Time) (*models.Review, error) {
+	cur := taskWeek(task.Start.Time, now, weekStart)
+	id := task.ID.Int64
+
+	pending, err := models.Reviews(
+		qm.Where("task = ? AND reviewed IS NULL AND week <= ?", id, cur),
+		qm.OrderBy("week ASC, id ASC"),
+	).One(ctx, exec)
+	if err == nil {
+		return pending, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	for _, w := range []int64{cur - 1, cur} {
+		if w < 1 {
+			continue
+		}
+		done, err := models.Reviews(qm.Where("task = ? AND week = ? AND reviewed IS NOT NULL", id, w)).Exists(ctx, exec)
+		if err != nil {
+			return nil, err
+		}
+		if !done {
+			return &models.Review{Task: id, Week: null.Int64From(w)}, nil
+		}
+	}
+	return nil, nil
+}
+
+// reviewForWeek returns the task's review of a week, or a new unsaved one.
+func reviewForWeek(ctx context.Context, exec boil.ContextExecutor, taskID, week int64) (*models.Review, error) {
+	rev, err := models.Reviews(
+		qm.Where("task = ? AND week = ?", taskID, week),
+		qm.OrderBy("id ASC"),
+	).One(ctx, exec)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &models.Review{Task: taskID, Week: null.Int64From(week)}, nil
+	}
+	return rev, err
+}
+
+// taskWeekSpan returns the first and last day of a task-relative week.
+func taskWeekSpan(start time.Time, week int64, weekStart time.Weekday) (time.Time, time.Time) {
+	from := startOfWeek(start, weekStart).AddDate(0, 0, int(week-1)*7)
+	return from, from.AddDate(0, 0, 6)
+}
+
+// reviewDigest summarizes a week: minutes, sessions, feedback against the week
+// before, and milestones reached in it.
+func reviewDigest(ctx context.Context, exec boil.ContextExecutor, taskID int64, from, to, now time.Time) (string, error) {
+	window := func(from, to time.Time) (sessionAgg, error) {
+		aggs, err := aggregateSessions(ctx, exec, bucketTask, sessionFilter{Task: taskID, From: from, To: to})
+		if err != nil || len(aggs) == 0 {
+			return sessionAgg{}, err
+		}
+		return aggs[0], nil
+	}
+	cur, err := window(from, to)
+	if err != nil {
+		return "", err
+	}
+	prev, err := window(from.AddDate(0, 0, -7), from.AddDate(0, 0, -1))
+	if err != nil {
+		return "", err
+	}
+
+	parts := []string{fmt.Sprintf("%d min in %s over %s",
+		cur.Total, plural(int(cur.Sessions), "session"), plural(int(cur.Days), "day"))}
+
+	switch {
+	case cur.Feedback.Valid && prev.Feedback.Valid:
+		trend := "steady"
+		if d := cur.Feedback.Float64 - prev.Feedback.Float64; d >= 0.25 {
+			trend = "up"
+		} else if d <= -0.25 {
+			trend = "down"
+		}
+		parts = append(parts, fmt.Sprintf("feedback %.1f, %s from %.1f", cur.Feedback.Float64, trend, prev.Feedback.Float64))
+	case cur.Feedback.Valid:
+		parts = append(parts, fmt.Sprintf("feedback %.1f", cur.Feedback.Float64))
+	}
+
+	last := to
+	if day := dateOnly(now); day.Before(last) {
+		last = day
+	}
+	hit, err := models.Milestones(
+		qm.Where("task = ?", taskID),
+		qm.Where("date(done) BETWEEN ? AND ?", from.Format(DateYMD), last.Format(DateYMD)),
+		qm.OrderBy("done ASC"),
+	).All(ctx, exec)
+	if err != nil {
+		return "", err
+	}
+	if len(hit) > 0 {
+		names := make([]string, len(hit))
+		for i, m := range hit {
+			names[i] = m.Type.String
+		}
+		parts = append(parts, "milestones: "+strings.Join(names, ", "))
+	}
+	return strings.Join(parts, "; "), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -48,34 +48,47 @@ import (
 var sessionCmd = &cobra.Command{
 	Use:               "session",
 	Short:             "Manage work sessions",
+	Example:           exampleSession,
 	PersistentPreRun:  dbPreRun,
 	PersistentPostRun: dbPostRun,
 }
 
 var sessionAddCmd = &cobra.Command{
 	Use:   "add",
-	Short: "Interactive TUI to add a new session",
+	Short: "Add a new session (flags or interactive TUI)",
 	Run:   runSessionAdd,
 }
 
 var sessionEditCmd = &cobra.Command{
 	Use:   "edit [id]",
-	Short: "Interactive TUI to edit an existing session",
+	Short: "Edit an existing session (flags or interactive TUI)",
 	Args:  cobra.ExactArgs(1),
 	Run:   runSessionEdit,
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
+var flagSessionListTag string
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
 func init() {
 	rootCmd.AddCommand(sessionCmd)
 	sessionCmd.AddCommand(sessionAddCmd, sessionEditCmd)
 
-	RegisterCrudSubcommands(sessionCmd, "sisu.db", CrudModel[*models.Session]{
+	scale := settingDefaultInt(settingFeedbackScale)
+	BindFieldFlags(sessionAddCmd, sessionFields(&models.Session{}, scale))
+	BindFieldFlags(sessionEditCmd, sessionFields(&models.Session{}, scale))
+
+	RegisterCrudSubcommands(sessionCmd, resolveDBPath, CrudModel[*models.Session]{
 		Singular: "session",
 
 		ListFn: func(ctx context.Context, conn *sql.DB) ([]*models.Session, error) {
-			return models.Sessions(qm.OrderBy("id ASC")).All(ctx, conn)
+			return models.Sessions(withTaskTag("task", flagSessionListTag, qm.OrderBy("id ASC"))...).All(ctx, conn)
+		},
+		ListFlags: func(list *cobra.Command) {
+			list.Flags().StringVar(&flagSessionListTag, "tag", "", "only list sessions of tasks with this tag")
+			_ = list.RegisterFlagCompletionFunc("tag", completeTagNames)
 		},
 
 		// Optional legacy fallback
@@ -135,31 +148,40 @@ func init() {
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// TODO: add default date today
+// sessionFields is shared by add and edit and registers their flags; initials come from sess.
+func sessionFields(sess *models.Session, scale int64) []Field {
+	return []Field{
+		FInt("Task ID", "Task", IDInitial(sess.Task)),
+		FOptString("Class (optional)", "Class", OptStringInitial(sess.Class)),
+		FOptDate("Session date (YYYY-MM-DD, optional)", "Date", OptTimeInitial(sess.Date, DateYMD)),
+		FOptInt("Duration (minutes, optional)", "Mins", OptInt64Initial(sess.Mins)),
+		FOptInt(fmt.Sprintf("Score (1–%d, optional)", scale), "Feedback", OptInt64Initial(sess.Feedback), WithValidate(VIntRange(1, scale))),
+		FOptString("Notes (optional)", "Notes", OptStringInitial(sess.Notes)),
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // TODO: add menu selector for tasks?
-func runSessionAdd(_ *cobra.Command, _ []string) {
-	sess := &models.Session{}
-
-	fields := []Field{
-		FInt("Task ID", "Task", ""),
-		FOptString("Class (optional)", "Class", ""), // <── new
-		FOptDate("Session date (YYYY-MM-DD, optional)", "Date", ""),
-		FOptInt("Duration (minutes, optional)", "Mins", ""),
-		FOptInt("Score (1–5, optional)", "Feedback", ""),
-		FOptString("Notes (optional)", "Notes", ""),
+func runSessionAdd(cmd *cobra.Command, _ []string) {
+	// dated today unless --date says otherwise; undated sessions drop out of stats and streaks
+	sess := &models.Session{Date: null.TimeFrom(today())}
+	if class := settingString(settingSessionClass); class != "" {
+		sess.Class = null.StringFrom(class)
 	}
 
-	RunFormWizard(fields, sess)
+	RunForm(cmd, sessionFields(sess, settingInt(settingFeedbackScale)), sess)
 
 	if err := sess.Insert(context.Background(), db.Conn, boil.Infer()); err != nil {
 		log.Fatalf("insert session: %v", err)
 	}
 	fmt.Printf("Created session %d\n", sess.ID.Int64)
+	announceMilestones(context.Background(), db.Conn, sess.Task)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func runSessionEdit(_ *cobra.Command, args []string) {
+func runSessionEdit(cmd *cobra.Command, args []string) {
 	idNum, err := strconv.ParseInt(args[0], 10, 64)
 	if err != nil {
 		log.Fatalf("invalid session ID: %v", err)
@@ -170,21 +192,10 @@ func runSessionEdit(_ *cobra.Command, args []string) {
 		log.Fatalf("find session: %v", err)
 	}
 
-	fields := []Field{
-		FInt("Task ID", "Task", strconv.FormatInt(sess.Task, 10)),
-		FOptString("Class (optional)", "Class", OptStringInitial(sess.Class)), // <── new
-		FOptDate("Session date (YYYY-MM-DD, optional)", "Date", OptTimeInitial(sess.Date, DateYMD)),
-		FOptInt("Duration (minutes, optional)", "Mins", OptInt64Initial(sess.Mins)),
-		FOptInt("Score (1–5, optional)", "Feedback", OptInt64Initial(sess.Feedback)),
-		FOptString("Notes (optional)", "Notes", OptStringInitial(sess.Notes)),
-	}
-
-	RunFormWizard(fields, sess)
+	RunEditForm(cmd, sessionFields(sess, settingInt(settingFeedbackScale)), sess)
 
 	if _, err := sess.Update(context.Background(), db.Conn, boil.Infer()); err != nil {
 		log.Fatalf("update session: %v", err)
 	}
 	fmt.Printf("Updated session %d\n", sess.ID.Int64)
 }
-
-////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,287 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Sisu/db"
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var statsCmd = &cobra.Command{
+	Use:               "stats [task]",
+	Short:             "Summarize sessions per task or per day, week, month or class",
+	Long:              helpStats,
+	Example:           exampleStats,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTaskArg,
+	PersistentPreRun:  dbPreRun,
+	PersistentPostRun: dbPostRun,
+	Run:               runStats,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	flagStatsFrom string
+	flagStatsTo   string
+	flagStatsBy   string
+	flagStatsTag  string
+)
+
+var statsGroupings = []string{"day", "week", "month", "class"}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().StringVar(&flagStatsFrom, "from", "", "first date to include (YYYY-MM-DD, -30d, last mon, ...)")
+	statsCmd.Flags().StringVar(&flagStatsTo, "to", "", "last date to include (default today)")
+	statsCmd.Flags().StringVar(&flagStatsBy, "by", "", "group by day|week|month|class instead of by task")
+	statsCmd.Flags().StringVar(&flagStatsTag, "tag", "", "only count tasks with this tag")
+
+	_ = statsCmd.RegisterFlagCompletionFunc("by", cobra.FixedCompletions(statsGroupings, cobra.ShellCompDirectiveNoFileComp))
+	_ = statsCmd.RegisterFlagCompletionFunc("tag", completeTagNames)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runStats(_ *cobra.Command, args []string) {
+	ctx := db.Ctx()
+	now := time.Now()
+
+	f := sessionFilter{Tag: flagStatsTag}
+	var err error
+	if flagStatsFrom != "" {
+		if f.From, err = parseRelativeDate(flagStatsFrom, now); err != nil {
+			log.Fatalf("parse --from: %v", err)
+		}
+	}
+	if flagStatsTo != "" {
+		if f.To, err = parseRelativeDate(flagStatsTo, now); err != nil {
+			log.Fatalf("parse --to: %v", err)
+		}
+	}
+
+	taskMods := withTaskTag("id", flagStatsTag, qm.OrderBy("id ASC"))
+	if len(args) == 1 {
+		task, err := resolveTask(ctx, db.Conn, args[0])
+		if err != nil {
+			log.Fatalf("resolve task: %v", err)
+		}
+		f.Task = task.ID.Int64
+		taskMods = append(taskMods, qm.Where("id = ?", f.Task))
+	}
+	tasks, err := models.Tasks(taskMods...).All(ctx, db.Conn)
+	if err != nil {
+		log.Fatalf("list tasks: %v", err)
+	}
+
+	switch flagStatsBy {
+	case "":
+		statsByTask(f, tasks)
+	case "day", "week", "month", "class":
+		statsByBucket(f, tasks, flagStatsBy)
+	default:
+		log.Fatalf("invalid --by %q (use day, week, month or class)", flagStatsBy)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var statsHeaders = []string{"sessions", "total", "mean", "days", "feedback", "hit"}
+
+// statsByTask prints one row per task; hit is adherence to the task's schedule over
+// start..target, clipped to the range and today.
+func statsByTask(f sessionFilter, tasks models.TaskSlice) {
+	ctx := db.Ctx()
+	aggs, err := aggregateSessions(ctx, db.Conn, bucketTask, f)
+	if err != nil {
+		log.Fatalf("aggregate sessions: %v", err)
+	}
+	rules, err := defaultStreakRules(ctx, db.Conn)
+	if err != nil {
+		log.Fatalf("load streak rules: %v", err)
+	}
+	byTask := make(map[string]sessionAgg, len(aggs))
+	for _, a := range aggs {
+		byTask[a.Bucket] = a
+	}
+
+	var rows [][]string
+	for _, t := range tasks {
+		a, ok := byTask[strconv.FormatInt(t.ID.Int64, 10)]
+		if !ok && f.Task == 0 {
+			continue
+		}
+		hit := ""
+		if t.Start.Valid {
+			if r, ok := taskHitRate(t, f, rules.forTask(t)); ok {
+				hit = formatPercent(r)
+			}
+		}
+		rows = append(rows, append([]string{strconv.FormatInt(t.ID.Int64, 10), t.Name}, statsCells(a, hit)...))
+	}
+
+	if len(rows) > 1 {
+		all, err := aggregateSessions(ctx, db.Conn, bucketAll, f)
+		if err != nil {
+			log.Fatalf("aggregate sessions: %v", err)
+		}
+		if len(all) == 1 {
+			rows = append(rows, append([]string{"", "all"}, statsCells(all[0], "")...))
+		}
+	}
+
+	if len(rows) == 0 {
+		fmt.Println("No sessions found.")
+		return
+	}
+	fmt.Println(RenderTable(append([]string{"id", "task"}, statsHeaders...), rows))
+}
+
+// statsByBucket prints one row per day, week, month or class across the selected tasks.
+func statsByBucket(f sessionFilter, tasks models.TaskSlice, by string) {
+	weekStart := settingWeekday(settingWeekStart)
+	bucket := map[string]string{
+		"day":   bucketDay,
+		"week":  bucketWeek(weekStart),
+		"month": bucketMonth,
+		"class": bucketClass,
+	}[by]
+
+	aggs, err := aggregateSessions(db.Ctx(), db.Conn, bucket, f)
+	if err != nil {
+		log.Fatalf("aggregate sessions: %v", err)
+	}
+	if len(aggs) == 0 {
+		fmt.Println("No sessions found.")
+		return
+	}
+
+	// hit spans never reach before the earliest task start
+	var earliest time.Time
+	for _, t := range tasks {
+		if t.Start.Valid && (earliest.IsZero() || t.Start.Time.Before(earliest)) {
+			earliest = dateOnly(t.Start.Time)
+		}
+	}
+
+	rows := make([][]string, 0, len(aggs))
+	for _, a := range aggs {
+		hit := ""
+		if from, to, ok := bucketSpan(by, a.Bucket); ok {
+			lo, hi := clipSpan(from, null.TimeFrom(to), f)
+			if !earliest.IsZero() && lo.Before(earliest) {
+				lo = earliest
+			}
+			if r, ok := hitRate(a.Days, lo, hi); ok {
+				hit = formatPercent(r)
+			}
+		}
+		rows = append(rows, append([]string{a.Bucket}, statsCells(a, hit)...))
+	}
+	fmt.Println(RenderTable(append([]string{by}, statsHeaders...), rows))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func statsCells(a sessionAgg, hit string) []string {
+	mean, fb := "", ""
+	if a.Mean.Valid {
+		mean = fmt.Sprintf("%.1f", a.Mean.Float64)
+	}
+	if a.Feedback.Valid {
+		fb = fmt.Sprintf("%.2f", a.Feedback.Float64)
+	}
+	return []string{
+		strconv.FormatInt(a.Sessions, 10),
+		strconv.FormatInt(a.Total, 10),
+		mean,
+		strconv.FormatInt(a.Days, 10),
+		fb,
+		hit,
+	}
+}
+
+// taskHitRate is the share of scheduled sessions kept inside the task's clipped
+// start..target span; excused days and an unlogged today owe nothing.
+func taskHitRate(t *models.Task, f sessionFilter, rules streakRules) (float64, bool) {
+	lo, hi := clipSpan(t.Start.Time, t.Target, f)
+	if hi.Before(lo) {
+		return 0, false
+	}
+	active, err := activeDays(db.Ctx(), db.Conn, t.ID.Int64)
+	if err != nil {
+		log.Fatalf("aggregate sessions: %v", err)
+	}
+	kept, slots := rules.Schedule.adherence(active, rules.Excused, lo, hi, rules.WeekStart, time.Now())
+	if slots == 0 {
+		return 0, false
+	}
+	return float64(kept) / float64(slots), true
+}
+
+// clipSpan bounds [start, end] by the filter range and today; a missing end means today.
+func clipSpan(start time.Time, end null.Time, f sessionFilter) (time.Time, time.Time) {
+	lo, hi := dateOnly(start), today()
+	if end.Valid && dateOnly(end.Time).Before(hi) {
+		hi = dateOnly(end.Time)
+	}
+	if !f.From.IsZero() && f.From.After(lo) {
+		lo = f.From
+	}
+	if !f.To.IsZero() && f.To.Before(hi) {
+		hi = f.To
+	}
+	return lo, hi
+}
+
+// bucketSpan returns the calendar days covered by a day, week or month bucket.
+func bucketSpan(by, bucket string) (time.Time, time.Time, bool) {
+	switch by {
+	case "day":
+		d, err := time.Parse(DateYMD, bucket)
+		return d, d, err == nil
+	case "week":
+		d, err := time.Parse(DateYMD, bucket)
+		return d, d.AddDate(0, 0, 6), err == nil
+	case "month":
+		d, err := time.Parse("2006-01", bucket)
+		return d, d.AddDate(0, 1, -1), err == nil
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+func formatPercent(r float64) string {
+	return fmt.Sprintf("%.0f%%", r*100)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
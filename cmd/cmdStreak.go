@@ -0,0 +1,161 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Sisu/db"
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var streakCmd = &cobra.Command{
+	Use:               "streak [task]",
+	Short:             "Show current and longest streak per task",
+	Long:              helpStreak,
+	Example:           exampleStreak,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTaskArg,
+	PersistentPreRun:  dbPreRun,
+	PersistentPostRun: dbPostRun,
+	Run:               runStreak,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	flagStreakTag   string
+	flagStreakGrace int
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(streakCmd)
+
+	streakCmd.Flags().StringVar(&flagStreakTag, "tag", "", "only show tasks with this tag")
+	streakCmd.Flags().IntVar(&flagStreakGrace, "grace", 0, "missed days per week that do not break a streak (default from the streak_grace setting)")
+	_ = streakCmd.RegisterFlagCompletionFunc("tag", completeTagNames)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runStreak(cmd *cobra.Command, args []string) {
+	ctx := db.Ctx()
+
+	rules, err := defaultStreakRules(ctx, db.Conn)
+	if err != nil {
+		log.Fatalf("load streak rules: %v", err)
+	}
+	if cmd.Flags().Changed("grace") {
+		if flagStreakGrace < 0 || flagStreakGrace > 6 {
+			log.Fatalf("--grace must be between 0 and 6")
+		}
+		rules.Grace = flagStreakGrace
+	}
+
+	var tasks models.TaskSlice
+	if len(args) == 1 {
+		task, err := resolveTask(ctx, db.Conn, args[0])
+		if err != nil {
+			log.Fatalf("resolve task: %v", err)
+		}
+		tasks = models.TaskSlice{task}
+	} else {
+		tasks, err = models.Tasks(withTaskTag("id", flagStreakTag,
+			qm.Where("coalesce(archived, 0) = 0"),
+			qm.OrderBy("id ASC"),
+		)...).All(ctx, db.Conn)
+		if err != nil {
+			log.Fatalf("list tasks: %v", err)
+		}
+	}
+
+	var rows [][]string
+	var notes []string
+	for _, t := range tasks {
+		res, err := taskStreaks(ctx, db.Conn, t, rules)
+		if err != nil {
+			log.Fatalf("streak for task %d: %v", t.ID.Int64, err)
+		}
+		rows = append(rows, []string{
+			strconv.FormatInt(t.ID.Int64, 10),
+			t.Name,
+			strconv.Itoa(res.Current.Days),
+			streakSpan(res.Current),
+			strconv.Itoa(res.Longest.Days),
+			streakSpan(res.Longest),
+		})
+		if line := recordLine(res, taskSchedule(t)); line != "" {
+			notes = append(notes, fmt.Sprintf("%s: %s", t.Name, line))
+		}
+	}
+
+	if len(rows) == 0 {
+		fmt.Println("No tasks found.")
+		return
+	}
+	fmt.Println(RenderTable([]string{"id", "task", "current", "current span", "longest", "longest span"}, rows))
+	for _, n := range notes {
+		fmt.Println(n)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func streakSpan(s streak) string {
+	if s.Days == 0 {
+		return ""
+	}
+	return s.Start.Format(DateYMD) + ".." + s.End.Format(DateYMD)
+}
+
+// recordLine tells how many more active days beat the longest streak; off a daily
+// schedule those are the sessions it calls for, not calendar days.
+func recordLine(res streakResult, sc schedule) string {
+	switch {
+	case res.Longest.Days == 0:
+		return ""
+	case res.Current.Days > 0 && res.Current.Start.Equal(res.Longest.Start):
+		return fmt.Sprintf("on a record streak of %s", plural(res.Current.Days, "day"))
+	}
+	need := res.Longest.Days - res.Current.Days + 1
+	unit := "day"
+	if sc.Kind != scheduleDaily {
+		unit = "scheduled session"
+	}
+	return fmt.Sprintf("%s until you beat your record of %d", plural(need, unit), res.Longest.Days)
+}
+
+// plural renders a count with its noun, adding "s" unless n is 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
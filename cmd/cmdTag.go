@@ -0,0 +1,227 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Sisu/db"
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Schema (tags, tasks_tags):
+//   tags.id INTEGER PK
+//   tags.name TEXT NOT NULL UNIQUE   → string (lowercase, no commas)
+//   tasks_tags(task, tag)            → many-to-many join, Task.Tags / Tag.Tasks
+
+var tagCmd = &cobra.Command{
+	Use:               "tag",
+	Short:             "Manage task categories",
+	Long:              helpTag,
+	Example:           exampleTag,
+	PersistentPreRun:  dbPreRun,
+	PersistentPostRun: dbPostRun,
+}
+
+var tagListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tags with their task counts",
+	Args:  cobra.NoArgs,
+	Run:   runTagList,
+}
+
+var tagAddCmd = &cobra.Command{
+	Use:   "add <name>...",
+	Short: "Create one or more tags",
+	Args:  cobra.MinimumNArgs(1),
+	Run:   runTagAdd,
+}
+
+var tagRmCmd = &cobra.Command{
+	Use:               "rm <name>...",
+	Short:             "Remove tags and detach them from every task",
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeTagNames,
+	Run:               runTagRm,
+}
+
+var tagRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a tag",
+	Args:  cobra.ExactArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeTagNames(cmd, args, toComplete)
+	},
+	Run: runTagRename,
+}
+
+var tagMergeCmd = &cobra.Command{
+	Use:               "merge <from>... <into>",
+	Short:             "Move every task from the source tags onto <into> and delete the sources",
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeTagNames,
+	Run:               runTagMerge,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(tagCmd)
+	tagCmd.AddCommand(tagListCmd, tagAddCmd, tagRmCmd, tagRenameCmd, tagMergeCmd)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runTagList(_ *cobra.Command, _ []string) {
+	tags, err := models.Tags(
+		qm.OrderBy("name ASC"),
+		qm.Load(models.TagRels.Tasks),
+	).All(db.Ctx(), db.Conn)
+	if err != nil {
+		log.Fatalf("list tags: %v", err)
+	}
+
+	rows := make([][]string, 0, len(tags))
+	for _, t := range tags {
+		rows = append(rows, []string{
+			strconv.FormatInt(t.ID.Int64, 10),
+			t.Name,
+			strconv.Itoa(len(t.GetTasks())),
+		})
+	}
+	fmt.Println(RenderTable([]string{"id", "name", "tasks"}, rows))
+}
+
+func runTagAdd(_ *cobra.Command, args []string) {
+	ctx := db.Ctx()
+	for _, raw := range args {
+		name, err := normalizeTag(raw)
+		if err != nil {
+			log.Fatalf("add tag: %v", err)
+		}
+		if _, err := findTag(ctx, db.Conn, name); err == nil {
+			fmt.Printf("Tag %q already exists\n", name)
+			continue
+		}
+		if _, err := ensureTags(ctx, db.Conn, []string{name}); err != nil {
+			log.Fatalf("add tag: %v", err)
+		}
+		fmt.Printf("Created tag %q\n", name)
+	}
+}
+
+func runTagRm(_ *cobra.Command, args []string) {
+	ctx := db.Ctx()
+	for _, raw := range args {
+		t, err := findTag(ctx, db.Conn, raw)
+		if err != nil {
+			log.Fatalf("rm tag: %v", err)
+		}
+		// foreign keys are not enforced per-connection, so detach explicitly
+		if err := t.SetTasks(ctx, db.Conn, false); err != nil {
+			log.Fatalf("detach tag %q: %v", t.Name, err)
+		}
+		if _, err := t.Delete(ctx, db.Conn); err != nil {
+			log.Fatalf("rm tag %q: %v", t.Name, err)
+		}
+		fmt.Printf("Removed tag %q\n", t.Name)
+	}
+}
+
+func runTagRename(_ *cobra.Command, args []string) {
+	ctx := db.Ctx()
+	t, err := findTag(ctx, db.Conn, args[0])
+	if err != nil {
+		log.Fatalf("rename tag: %v", err)
+	}
+	name, err := normalizeTag(args[1])
+	if err != nil {
+		log.Fatalf("rename tag: %v", err)
+	}
+	if _, err := findTag(ctx, db.Conn, name); err == nil {
+		log.Fatalf("tag %q already exists; use `sisu tag merge %s %s`", name, t.Name, name)
+	}
+
+	old := t.Name
+	t.Name = name
+	if _, err := t.Update(ctx, db.Conn, boil.Whitelist("name")); err != nil {
+		log.Fatalf("rename tag %q: %v", old, err)
+	}
+	fmt.Printf("Renamed tag %q → %q\n", old, name)
+}
+
+func runTagMerge(_ *cobra.Command, args []string) {
+	ctx := db.Ctx()
+	sources, target := args[:len(args)-1], args[len(args)-1]
+
+	tx, err := db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Fatalf("begin merge: %v", err)
+	}
+	defer tx.Rollback()
+
+	name, err := normalizeTag(target)
+	if err != nil {
+		log.Fatalf("merge tags: %v", err)
+	}
+	into, err := ensureTags(ctx, tx, []string{name})
+	if err != nil {
+		log.Fatalf("merge tags: %v", err)
+	}
+
+	for _, raw := range sources {
+		from, err := findTag(ctx, tx, raw)
+		if err != nil {
+			log.Fatalf("merge tags: %v", err)
+		}
+		if from.ID == into[0].ID {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT OR IGNORE INTO tasks_tags (task, tag) SELECT task, ? FROM tasks_tags WHERE tag = ?`,
+			into[0].ID, from.ID,
+		); err != nil {
+			log.Fatalf("move tasks from %q: %v", from.Name, err)
+		}
+		if err := from.SetTasks(ctx, tx, false); err != nil {
+			log.Fatalf("detach tag %q: %v", from.Name, err)
+		}
+		if _, err := from.Delete(ctx, tx); err != nil {
+			log.Fatalf("rm tag %q: %v", from.Name, err)
+		}
+		fmt.Printf("Merged tag %q into %q\n", from.Name, name)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Fatalf("commit merge: %v", err)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -41,11 +41,13 @@ import (
 // Schema (tasks):
 //   - id integer PK
 //   - name text NOT NULL            → string
-//   - tag text                      → null.String
+//   - tags                          → many-to-many via tasks_tags (see cmdTag.go)
 //   - description text              → null.String
 //   - target datetime               → null.Time
 //   - start datetime                → null.Time
 //   - archived boolean DEFAULT FALSE → null.Bool (likely; confirm after regen)
+//   - goal integer                  → null.Int64 (minutes by target)
+//   - schedule text                 → null.String (see utilSchedule.go; NULL is daily)
 
 var taskCmd = &cobra.Command{
 	Use:               "task",
@@ -58,13 +60,13 @@ var taskCmd = &cobra.Command{
 
 var taskAddCmd = &cobra.Command{
 	Use:   "add",
-	Short: "Interactive TUI to add a new task",
+	Short: "Add a new task (flags or interactive TUI)",
 	Run:   runTaskAdd,
 }
 
 var taskEditCmd = &cobra.Command{
 	Use:   "edit [id]",
-	Short: "Interactive TUI to edit a task",
+	Short: "Edit a task (flags or interactive TUI)",
 	Args:  cobra.ExactArgs(1),
 	Run:   runTaskEdit,
 }
@@ -91,6 +93,7 @@ var (
 	flagArchiveOn     bool
 	flagArchiveOff    bool
 	flagArchiveToggle bool
+	flagTaskListTag   string
 )
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -98,6 +101,8 @@ var (
 func init() {
 	rootCmd.AddCommand(taskCmd)
 	taskCmd.AddCommand(taskAddCmd, taskEditCmd)
+	BindFieldFlags(taskAddCmd, taskAddFields(&taskAddForm{}, []string{"default", profileNone}, settingDefaultInt(settingTargetDays), time.Now(), nil))
+	BindFieldFlags(taskEditCmd, taskEditFields(&models.Task{}, new(string)))
 	taskCmd.AddCommand(taskArchiveCmd)
 
 	// Flags: mutually exclusive; if none set, default is --toggle
@@ -105,15 +110,22 @@ func init() {
 	taskArchiveCmd.Flags().BoolVar(&flagArchiveOff, "off", false, "set archived = false")
 	taskArchiveCmd.Flags().BoolVar(&flagArchiveToggle, "toggle", false, "toggle archived (default)")
 
-	RegisterCrudSubcommands(taskCmd, "sisu.db", CrudModel[*models.Task]{
+	RegisterCrudSubcommands(taskCmd, resolveDBPath, CrudModel[*models.Task]{
 		Singular: "task",
 
 		ListFn: func(ctx context.Context, conn *sql.DB) ([]*models.Task, error) {
-			return models.Tasks(qm.OrderBy("id ASC")).All(ctx, conn)
+			return models.Tasks(withTaskTag("id", flagTaskListTag,
+				qm.OrderBy("id ASC"),
+				qm.Load(models.TaskRels.Tags),
+			)...).All(ctx, conn)
+		},
+		ListFlags: func(list *cobra.Command) {
+			list.Flags().StringVar(&flagTaskListTag, "tag", "", "only list tasks with this tag")
+			_ = list.RegisterFlagCompletionFunc("tag", completeTagNames)
 		},
 
 		Format: func(t *models.Task) (int64, string) {
-			tag := t.Tag.String
+			tag := taskTagsString(t)
 			desc := t.Description.String
 			target := ""
 			if t.Target.Valid {
@@ -123,12 +135,12 @@ func init() {
 			if t.Start.Valid {
 				start = t.Start.Time.Format(time.RFC3339)
 			}
-			return t.ID.Int64, fmt.Sprintf("name=%s tag=%s target=%s start=%s archived=%v desc=%s",
+			return t.ID.Int64, fmt.Sprintf("name=%s tags=%s target=%s start=%s archived=%v desc=%s",
 				t.Name, tag, target, start, t.Archived.Bool, desc)
 		},
 
 		// pretty table
-		TableHeaders: []string{"id", "name", "tag", "description", "start", "target", "archived"},
+		TableHeaders: []string{"id", "name", "tags", "description", "start", "target", "goal", "schedule", "archived"},
 		TableRow: func(t *models.Task) []string {
 			start := ""
 			if t.Start.Valid {
@@ -139,6 +151,10 @@ func init() {
 			if t.Target.Valid {
 				target = t.Target.Time.Format(time.RFC3339)
 			}
+			goal := ""
+			if t.Goal.Valid {
+				goal = strconv.FormatInt(t.Goal.Int64, 10)
+			}
 			archived := "0"
 			if t.Archived.Bool {
 				archived = "1"
@@ -146,10 +162,12 @@ func init() {
 			return []string{
 				strconv.FormatInt(t.ID.Int64, 10),
 				t.Name,
-				t.Tag.String,
+				taskTagsString(t),
 				t.Description.String,
 				start,
 				target,
+				goal,
+				taskSchedule(t).String(),
 				archived,
 			}
 		},
@@ -159,6 +177,16 @@ func init() {
 			if err != nil {
 				return err
 			}
+			if err := task.SetTags(ctx, conn, false); err != nil {
+				return err
+			}
+			// coach rules scoped to the task go with it; shared rules stay
+			if _, err := models.CoachDeliveries(qm.Where("task = ? OR coach IN (SELECT id FROM coach WHERE task = ?)", id, id)).DeleteAll(ctx, conn); err != nil {
+				return err
+			}
+			if _, err := models.Coaches(qm.Where("task = ?", id)).DeleteAll(ctx, conn); err != nil {
+				return err
+			}
 			_, err = task.Delete(ctx, conn)
 			return err
 		},
@@ -174,14 +202,14 @@ func init() {
 			if t.Archived.Bool {
 				archived = "1"
 			}
-			return fmt.Sprintf("name, %s tag, %s start, %s target, %s archived, %s",
-				t.Name, t.Tag.String, start, target, archived)
+			return fmt.Sprintf("name, %s tags, %s start, %s target, %s archived, %s",
+				t.Name, taskTagsString(t), start, target, archived)
 		},
 	})
 
 	AttachEditCompletion(taskEditCmd,
 		func(ctx context.Context, conn *sql.DB) ([]*models.Task, error) {
-			return models.Tasks(qm.OrderBy("id ASC")).All(ctx, conn)
+			return models.Tasks(qm.OrderBy("id ASC"), qm.Load(models.TaskRels.Tags)).All(ctx, conn)
 		},
 		func(t *models.Task) (int64, string) { // format fallback (id + simple)
 			return t.ID.Int64, t.Name
@@ -198,8 +226,8 @@ func init() {
 			if t.Archived.Bool {
 				archived = "1"
 			}
-			return fmt.Sprintf("name, %s tag, %s start, %s target, %s archived, %s",
-				t.Name, t.Tag.String, start, target, archived)
+			return fmt.Sprintf("name, %s tags, %s start, %s target, %s archived, %s",
+				t.Name, taskTagsString(t), start, target, archived)
 		},
 	)
 
@@ -207,33 +235,45 @@ func init() {
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func runTaskAdd(_ *cobra.Command, _ []string) {
-	task := &models.Task{}
-
-	// capture Start for computing Target default & profile seeding
-	var startPicked null.Time
-
-	today := time.Now()
-	todayStr := today.Format(DateYMD)
-
-	profileChoice := "default"
+// taskAddForm collects the add-form values that do not live on models.Task.
+type taskAddForm struct {
+	profile string
+	tags    string
+	start   null.Time // captured for the Target default & profile seeding
+}
 
-	fields := []Field{
-		FChoice("Profile (default/custom)", "default", []string{"default", "custom"},
-			func(v string) { profileChoice = v },
+// taskAddFields builds the add form; checkProfile, when set, vets the chosen profile
+// before any other field is asked for.
+func taskAddFields(form *taskAddForm, choices []string, targetDays int64, today time.Time, checkProfile func(name string) error) []Field {
+	form.profile = choices[0]
+	profileLabel := fmt.Sprintf("Profile (%s)", strings.Join(choices, "/"))
+
+	return []Field{
+		FChoice(profileLabel, form.profile, choices,
+			func(v string) { form.profile = v },
+			WithName("profile"),
+			WithValidate(func(s string) error {
+				if err := VRequired(profileLabel)(s); err != nil || checkProfile == nil {
+					return err
+				}
+				return checkProfile(strings.ToLower(strings.TrimSpace(s)))
+			}),
 		),
 		FString("Task name", "Name", ""),
-		FOptString("Tag (optional)", "Tag", ""),
+		FOptString("Tags (comma-separated, optional)", "", "",
+			WithName("tags"),
+			WithAssign(func(_ any, v any) { form.tags = v.(null.String).String }),
+		),
 		FOptString("Description (optional)", "Description", ""),
 		FOptDate("Start date (YYYY-MM-DD, optional)", "Start", "",
-			WithInitial(todayStr),
+			WithInitial(today.Format(DateYMD)),
 			WithValidate(VDateOptional()),
 			WithAssign(func(h any, v any) {
 				Assign("Start", h, v)
-				startPicked = v.(null.Time)
+				form.start = v.(null.Time)
 			}),
 		),
-		FOptDate("Target date (YYYY-MM-DD, optional) Default calculated to 100 days from Start date", "Target", "",
+		FOptDate(fmt.Sprintf("Target date (YYYY-MM-DD, optional) Default calculated to %d days from Start date", targetDays), "Target", "",
 			WithValidate(VDateOptional()),
 			WithParse(func(s string) (any, error) {
 				s = strings.TrimSpace(s)
@@ -241,128 +281,130 @@ func runTaskAdd(_ *cobra.Command, _ []string) {
 					return ParseOptDate(s)
 				}
 				base := today
-				if startPicked.Valid {
-					base = startPicked.Time
+				if form.start.Valid {
+					base = form.start.Time
 				}
-				return null.TimeFrom(base.AddDate(0, 0, 100)), nil
+				return null.TimeFrom(base.AddDate(0, 0, int(targetDays))), nil
 			}),
 		),
+		FOptInt("Goal (minutes by target, optional)", "Goal", "", WithValidate(VIntRange(1, 1_000_000))),
+		FOptString("Schedule (daily, mon,wed,fri, 3/week, every 2 days; optional)", "Schedule", "",
+			WithValidate(VSchedule), WithParse(parseOptSchedule)),
 	}
+}
 
-	RunFormWizard(fields, task)
-
-	if err := task.Insert(context.Background(), db.Conn, boil.Infer()); err != nil {
-		log.Fatalf("insert task: %v", err)
+func taskEditFields(task *models.Task, tags *string) []Field {
+	return []Field{
+		FString("Task name", "Name", task.Name),
+		FOptString("Tags (comma-separated, optional)", "", *tags,
+			WithName("tags"),
+			WithAssign(func(_ any, v any) { *tags = v.(null.String).String }),
+		),
+		FOptString("Description (optional)", "Description", OptStringInitial(task.Description)),
+		FOptDate("Start date (YYYY-MM-DD, optional)", "Start", OptTimeInitial(task.Start, DateYMD)),
+		FOptDate("Target date (YYYY-MM-DD, optional)", "Target", OptTimeInitial(task.Target, DateYMD)),
+		FOptInt("Goal (minutes by target, optional)", "Goal", OptInt64Initial(task.Goal), WithValidate(VIntRange(1, 1_000_000))),
+		FOptString("Schedule (daily, mon,wed,fri, 3/week, every 2 days; optional)", "Schedule", OptStringInitial(task.Schedule),
+			WithValidate(VSchedule), WithParse(parseOptSchedule)),
 	}
-	fmt.Printf("Created task %d\n", task.ID.Int64)
+}
 
-	// Seed using Start as base if provided
-	// base := today
-	// if startPicked.Valid {
-	// 	base = startPicked.Time
-	// }
-	if profileChoice == "default" {
-		// if err := seedDefaultTaskProfileWithBase(db.Ctx(), db.Conn, task.ID.Int64, base); err != nil {
-		// 	log.Fatalf("seed default profile: %v", err)
-		// }
-		fmt.Println("Applied default profile (milestones, reviews, coach).")
+// parseOptSchedule stores a schedule in its canonical form, e.g. "Mon, Fri" as "mon,fri".
+func parseOptSchedule(s string) (any, error) {
+	if strings.TrimSpace(s) == "" {
+		return null.String{}, nil
+	}
+	sc, err := parseSchedule(s)
+	if err != nil {
+		return nil, err
 	}
+	return null.StringFrom(sc.String()), nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func runTaskEdit(_ *cobra.Command, args []string) {
-	rawID := args[0]
-	idNum, err := strconv.ParseInt(rawID, 10, 64)
+func runTaskAdd(cmd *cobra.Command, _ []string) {
+	task := &models.Task{}
+	today := time.Now()
+
+	available, err := loadProfiles()
 	if err != nil {
-		log.Fatalf("invalid task ID %q: %v", rawID, err)
+		log.Fatalf("load profiles: %v", err)
 	}
-	task, err := models.FindTask(context.Background(), db.Conn, null.Int64From(idNum))
+	choices := append(profileNames(available), profileNone)
+
+	// a broken profile fails at the first field, not after the whole form
+	checkProfile := func(name string) error {
+		if p := available[name]; p != nil {
+			if err := p.Validate(); err != nil {
+				return fmt.Errorf("profile %s (%s): %v", p.Name, p.Source, err)
+			}
+		}
+		return nil
+	}
+	form := &taskAddForm{}
+	RunForm(cmd, taskAddFields(form, choices, settingInt(settingTargetDays), today, checkProfile), task)
+
+	ctx := db.Ctx()
+	tx, err := db.Conn.BeginTx(ctx, nil)
 	if err != nil {
-		log.Fatalf("couldn't find task %d: %v", idNum, err)
+		log.Fatalf("begin task: %v", err)
 	}
+	defer tx.Rollback()
 
-	fields := []Field{
-		FString("Task name", "Name", task.Name),
-		FOptString("Tag (optional)", "Tag", task.Tag.String),
-		FOptString("Description (optional)", "Description", task.Description.String),
-		FOptDate("Start date (YYYY-MM-DD, optional)", "Start", OptTimeInitial(task.Start, DateYMD)),
-		FOptDate("Target date (YYYY-MM-DD, optional)", "Target", OptTimeInitial(task.Target, DateYMD)),
+	if err := task.Insert(ctx, tx, boil.Infer()); err != nil {
+		log.Fatalf("insert task: %v", err)
+	}
+	if err := setTaskTags(ctx, tx, task, parseTags(form.tags)); err != nil {
+		log.Fatalf("tag task: %v", err)
 	}
 
-	RunFormWizard(fields, task)
+	// Seed using Start as base if provided
+	profile := available[form.profile]
+	if profile != nil {
+		base := today
+		if form.start.Valid {
+			base = form.start.Time
+		}
+		if err := seedTaskProfile(ctx, tx, profile, task.ID.Int64, base); err != nil {
+			log.Fatalf("seed %s profile: %v", profile.Name, err)
+		}
+	}
 
-	if _, err := task.Update(context.Background(), db.Conn, boil.Infer()); err != nil {
-		log.Fatalf("update failed: %v", err)
+	if err := tx.Commit(); err != nil {
+		log.Fatalf("commit task: %v", err)
+	}
+	fmt.Printf("Created task %d\n", task.ID.Int64)
+	if profile != nil {
+		fmt.Printf("Applied %s profile (%d milestones, %d reviews, %d coach).\n",
+			profile.Name, len(profile.Milestones), len(profile.Reviews), len(profile.Coach))
 	}
-	fmt.Printf("Updated task %d\n", task.ID.Int64)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// TODO: update defaults; probably load from toml
-// seedDefaultTaskProfile seeds milestones, reviews, and coach defaults for a task.
-func seedDefaultTaskProfileWithBase(ctx context.Context, exec boil.ContextExecutor, taskID int64, base time.Time) error {
-	// Helpers for dates
-	addDays := func(n int) null.Time { return null.TimeFrom(base.AddDate(0, 0, n)) }
-
-	// 1) Milestones (three entries)
-	milestones := []*models.Milestone{
-		{
-			Task:    taskID,
-			Type:    null.StringFrom("courage"),
-			Value:   null.Int64From(2),
-			Done:    addDays(25),
-			Message: null.StringFrom("face difficulties with resolve"),
-		},
-		{
-			Task:    taskID,
-			Type:    null.StringFrom("determination"),
-			Value:   null.Int64From(3),
-			Done:    addDays(50),
-			Message: null.StringFrom("continue despite challenges"),
-		},
-		{
-			Task:    taskID,
-			Type:    null.StringFrom("perseverance"),
-			Value:   null.Int64From(4),
-			Done:    addDays(75),
-			Message: null.StringFrom("stay committed to the goal"),
-		},
+func runTaskEdit(cmd *cobra.Command, args []string) {
+	rawID := args[0]
+	idNum, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		log.Fatalf("invalid task ID %q: %v", rawID, err)
 	}
-	for _, m := range milestones {
-		if err := m.Insert(ctx, exec, boil.Infer()); err != nil {
-			return fmt.Errorf("insert milestone: %w", err)
-		}
+	task, err := models.Tasks(qm.Where("id = ?", idNum), qm.Load(models.TaskRels.Tags)).One(context.Background(), db.Conn)
+	if err != nil {
+		log.Fatalf("couldn't find task %d: %v", idNum, err)
 	}
 
-	// 2) Reviews: seven entries with weeks 2,4,6,8,10,12,14 and ordinal summaries
-	weeks := []int64{2, 4, 6, 8, 10, 12, 14}
-	ordinals := []string{"first", "second", "third", "fourth", "fifth", "sixth", "seventh"}
-	for i, w := range weeks {
-		r := &models.Review{
-			Task:    taskID,
-			Week:    null.Int64From(w),
-			Summary: null.StringFrom(ordinals[i] + ": "),
-		}
-		if err := r.Insert(ctx, exec, boil.Infer()); err != nil {
-			return fmt.Errorf("insert review (week %d): %w", w, err)
-		}
-	}
+	tagInput := taskTagsString(task)
 
-	// 3) Coach: three entries (date optional -> leave NULL)
-	coaches := []*models.Coach{
-		{Trigger: "forgotten", Content: "forgotten"},
-		{Trigger: "low performance", Content: "low performance"},
-		{Trigger: "superb", Content: "superb"},
+	RunEditForm(cmd, taskEditFields(task, &tagInput), task)
+
+	if _, err := task.Update(context.Background(), db.Conn, boil.Infer()); err != nil {
+		log.Fatalf("update failed: %v", err)
 	}
-	for _, c := range coaches {
-		if err := c.Insert(ctx, exec, boil.Infer()); err != nil {
-			return fmt.Errorf("insert coach: %w", err)
-		}
+	if err := setTaskTags(context.Background(), db.Conn, task, parseTags(tagInput)); err != nil {
+		log.Fatalf("tag task: %v", err)
 	}
-
-	return nil
+	fmt.Printf("Updated task %d\n", task.ID.Int64)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -453,8 +495,8 @@ func taskArchivedValidArgs(cmd *cobra.Command, args []string, toComplete string)
 		if t.Archived.Bool {
 			archived = "1"
 		}
-		return fmt.Sprintf("name, %s tag, %s start, %s target, %s archived, %s",
-			t.Name, t.Tag.String, start, target, archived)
+		return fmt.Sprintf("name, %s tags, %s start, %s target, %s archived, %s",
+			t.Name, taskTagsString(t), start, target, archived)
 	}
 
 	ctx := db.Ctx()
@@ -462,7 +504,7 @@ func taskArchivedValidArgs(cmd *cobra.Command, args []string, toComplete string)
 		ctx,
 		nil, // dbConn is ignored; buildIDCompletions ensures and uses db.Conn
 		func(ctx context.Context, conn *sql.DB) ([]*models.Task, error) {
-			return models.Tasks(qm.OrderBy("id ASC")).All(ctx, conn)
+			return models.Tasks(qm.OrderBy("id ASC"), qm.Load(models.TaskRels.Tags)).All(ctx, conn)
 		},
 		format,
 		toComplete,
@@ -0,0 +1,307 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Sisu/db"
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Schema (timers):
+//   id INTEGER PK
+//   task INTEGER NOT NULL UNIQUE  → int64 (one running session per task)
+//   class TEXT                    → null.String
+//   started DATETIME NOT NULL     → time.Time (wall clock at start)
+//   paused DATETIME               → null.Time (set while paused)
+//   paused_secs INTEGER NOT NULL  → int64 (paused time already accumulated)
+
+var sessionStartCmd = &cobra.Command{
+	Use:               "start <task>",
+	Short:             "Start a live session timer for a task",
+	Long:              helpSessionTimer,
+	Example:           exampleSessionTimer,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTaskArg,
+	Run:               runSessionStart,
+}
+
+var sessionStopCmd = &cobra.Command{
+	Use:               "stop [task]",
+	Short:             "Stop a running session and log it",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTaskArg,
+	Run:               runSessionStop,
+}
+
+var sessionPauseCmd = &cobra.Command{
+	Use:               "pause [task]",
+	Short:             "Pause a running session",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTaskArg,
+	Run:               runSessionPause,
+}
+
+var sessionResumeCmd = &cobra.Command{
+	Use:               "resume [task]",
+	Short:             "Resume a paused session",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTaskArg,
+	Run:               runSessionResume,
+}
+
+var sessionStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show running sessions and their elapsed time",
+	Args:  cobra.NoArgs,
+	Run:   runSessionStatus,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	flagTimerClass   string
+	flagTimerDiscard bool
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	sessionCmd.AddCommand(sessionStartCmd, sessionStopCmd, sessionPauseCmd, sessionResumeCmd, sessionStatusCmd)
+
+	sessionStartCmd.Flags().StringVar(&flagTimerClass, "class", "", "session class (default: session_class setting)")
+	sessionStopCmd.Flags().BoolVar(&flagTimerDiscard, "discard", false, "drop the running session without logging it")
+	BindFieldFlags(sessionStopCmd, sessionStopFields(settingDefaultInt(settingFeedbackScale)))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runSessionStart(cmd *cobra.Command, args []string) {
+	ctx := db.Ctx()
+	task, err := resolveTask(ctx, db.Conn, args[0])
+	if err != nil {
+		log.Fatalf("start session: %v", err)
+	}
+
+	if running, err := models.Timers(qm.Where("task = ?", task.ID)).One(ctx, db.Conn); err == nil {
+		log.Fatalf("task %d already running since %s", task.ID.Int64, running.Started.Format("15:04"))
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		log.Fatalf("start session: %v", err)
+	}
+
+	class := flagTimerClass
+	if !cmd.Flags().Changed("class") {
+		class = settingString(settingSessionClass)
+	}
+
+	t := &models.Timer{
+		Task:    task.ID.Int64,
+		Started: time.Now(),
+	}
+	if class != "" {
+		t.Class = null.StringFrom(class)
+	}
+	if err := t.Insert(ctx, db.Conn, boil.Infer()); err != nil {
+		log.Fatalf("start session: %v", err)
+	}
+	fmt.Printf("Started %s at %s\n", task.Name, t.Started.Format("15:04"))
+}
+
+// sessionStopFields are the prompts answered when a running session is logged.
+func sessionStopFields(scale int64) []Field {
+	return []Field{
+		FOptInt(fmt.Sprintf("Score (1–%d, optional)", scale), "Feedback", "", WithValidate(VIntRange(1, scale))),
+		FOptString("Notes (optional)", "Notes", ""),
+	}
+}
+
+func runSessionStop(cmd *cobra.Command, args []string) {
+	ctx := db.Ctx()
+	t := pickTimer(ctx, args)
+	elapsed := timerElapsed(t, time.Now())
+
+	if flagTimerDiscard {
+		if _, err := t.Delete(ctx, db.Conn); err != nil {
+			log.Fatalf("discard session: %v", err)
+		}
+		fmt.Printf("Discarded session for task %d (%s)\n", t.Task, formatClock(elapsed))
+		return
+	}
+
+	mins := int64(math.Round(elapsed.Minutes()))
+	if mins < 1 {
+		mins = 1
+	}
+
+	sess := &models.Session{
+		Task:  t.Task,
+		Class: t.Class,
+		Date:  null.TimeFrom(dateOnly(t.Started)),
+		Mins:  null.Int64From(mins),
+	}
+
+	fmt.Printf("Stopped after %s (%d min)\n", formatClock(elapsed), mins)
+	// the prompts are optional, so a TTY without flags still walks them
+	RunEditForm(cmd, sessionStopFields(settingInt(settingFeedbackScale)), sess)
+
+	tx, err := db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Fatalf("stop session: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := sess.Insert(ctx, tx, boil.Infer()); err != nil {
+		log.Fatalf("insert session: %v", err)
+	}
+	if _, err := t.Delete(ctx, tx); err != nil {
+		log.Fatalf("clear timer: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		log.Fatalf("stop session: %v", err)
+	}
+	fmt.Printf("Created session %d\n", sess.ID.Int64)
+	announceMilestones(ctx, db.Conn, sess.Task)
+}
+
+func runSessionPause(_ *cobra.Command, args []string) {
+	ctx := db.Ctx()
+	t := pickTimer(ctx, args)
+	if t.Paused.Valid {
+		log.Fatalf("task %d already paused since %s", t.Task, t.Paused.Time.Format("15:04"))
+	}
+
+	t.Paused = null.TimeFrom(time.Now())
+	if _, err := t.Update(ctx, db.Conn, boil.Whitelist("paused")); err != nil {
+		log.Fatalf("pause session: %v", err)
+	}
+	fmt.Printf("Paused task %d at %s\n", t.Task, formatClock(timerElapsed(t, time.Now())))
+}
+
+func runSessionResume(_ *cobra.Command, args []string) {
+	ctx := db.Ctx()
+	t := pickTimer(ctx, args)
+	if !t.Paused.Valid {
+		log.Fatalf("task %d is not paused", t.Task)
+	}
+
+	now := time.Now()
+	t.PausedSecs += int64(now.Sub(t.Paused.Time) / time.Second)
+	t.Paused = null.Time{}
+	if _, err := t.Update(ctx, db.Conn, boil.Whitelist("paused", "paused_secs")); err != nil {
+		log.Fatalf("resume session: %v", err)
+	}
+	fmt.Printf("Resumed task %d at %s\n", t.Task, formatClock(timerElapsed(t, now)))
+}
+
+func runSessionStatus(_ *cobra.Command, _ []string) {
+	ctx := db.Ctx()
+	timers, err := models.Timers(qm.OrderBy("started ASC")).All(ctx, db.Conn)
+	if err != nil {
+		log.Fatalf("session status: %v", err)
+	}
+	if len(timers) == 0 {
+		fmt.Println("No running sessions")
+		return
+	}
+
+	now := time.Now()
+	rows := make([][]string, 0, len(timers))
+	for _, t := range timers {
+		name := ""
+		if task, err := models.FindTask(ctx, db.Conn, null.Int64From(t.Task)); err == nil {
+			name = task.Name
+		}
+		state := "running"
+		if t.Paused.Valid {
+			state = "paused"
+		}
+		rows = append(rows, []string{
+			strconv.FormatInt(t.Task, 10),
+			name,
+			t.Class.String,
+			t.Started.Local().Format("2006-01-02 15:04"),
+			formatClock(timerElapsed(t, now)),
+			state,
+		})
+	}
+	fmt.Println(RenderTable([]string{"task", "name", "class", "started", "elapsed", "state"}, rows))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// pickTimer returns the timer for the task in args, or the only running one.
+func pickTimer(ctx context.Context, args []string) *models.Timer {
+	if len(args) == 1 {
+		task, err := resolveTask(ctx, db.Conn, args[0])
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		t, err := models.Timers(qm.Where("task = ?", task.ID)).One(ctx, db.Conn)
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Fatalf("no running session for task %d", task.ID.Int64)
+		} else if err != nil {
+			log.Fatalf("find running session: %v", err)
+		}
+		return t
+	}
+
+	timers, err := models.Timers().All(ctx, db.Conn)
+	if err != nil {
+		log.Fatalf("find running session: %v", err)
+	}
+	switch len(timers) {
+	case 0:
+		log.Fatalf("no running sessions; start one with `sisu session start <task>`")
+	case 1:
+		return timers[0]
+	default:
+		log.Fatalf("%d sessions running; name the task", len(timers))
+	}
+	return nil
+}
+
+// timerElapsed is wall time since start minus every paused stretch.
+func timerElapsed(t *models.Timer, now time.Time) time.Duration {
+	end := now
+	if t.Paused.Valid {
+		end = t.Paused.Time
+	}
+	d := end.Sub(t.Started) - time.Duration(t.PausedSecs)*time.Second
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,298 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Sisu/db"
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var todayCmd = &cobra.Command{
+	Use:               "today",
+	Short:             "Daily agenda: due tasks, minutes, reviews, milestones, calendar and coach",
+	Long:              helpToday,
+	Example:           exampleToday,
+	Args:              cobra.NoArgs,
+	PersistentPreRun:  dbPreRun,
+	PersistentPostRun: dbPostRun,
+	Run:               runToday,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	flagTodayTag   string
+	flagTodayQuiet bool
+)
+
+// milestones at or past this share of their target are listed as close
+const todayMilestoneNear = 0.8
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(todayCmd)
+
+	todayCmd.Flags().StringVar(&flagTodayTag, "tag", "", "only show tasks with this tag")
+	todayCmd.Flags().BoolVarP(&flagTodayQuiet, "quiet", "q", false, "print nothing; only set the exit status")
+	_ = todayCmd.RegisterFlagCompletionFunc("tag", completeTagNames)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runToday(_ *cobra.Command, _ []string) {
+	ctx := db.Ctx()
+	now := time.Now()
+
+	a, err := buildAgenda(ctx, db.Conn, flagTodayTag, now)
+	if err != nil {
+		log.Fatalf("build agenda: %v", err)
+	}
+
+	if !flagTodayQuiet {
+		a.render(os.Stdout, now)
+	}
+	if a.Overdue > 0 {
+		dbPostRun(nil, nil)
+		os.Exit(1)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// agenda is one day's view; each section holds rendered lines, and Overdue counts
+// the missed scheduled sessions, past-week reviews and past-due milestones in them.
+type agenda struct {
+	Tasks      []string
+	Logged     int64
+	Reviews    []string
+	Milestones []string
+	Calendar   []string
+	Coach      []string
+	Overdue    int
+}
+
+func buildAgenda(ctx context.Context, exec boil.ContextExecutor, tag string, now time.Time) (*agenda, error) {
+	day := dateOnly(now)
+	a := &agenda{}
+
+	tasks, err := models.Tasks(withTaskTag("id", tag,
+		qm.Where("coalesce(archived, 0) = 0 AND (start IS NULL OR date(start) <= ?)", day.Format(DateYMD)),
+		qm.OrderBy("id ASC"),
+	)...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := defaultStreakRules(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	logged, err := aggregateSessions(ctx, exec, bucketTask, sessionFilter{Tag: tag, From: day, To: day})
+	if err != nil {
+		return nil, err
+	}
+	mins := make(map[string]int64, len(logged))
+	for _, l := range logged {
+		mins[l.Bucket] = l.Total
+		a.Logged += l.Total
+	}
+
+	// scheduled tasks: logged today, due and open, or behind
+	for _, t := range tasks {
+		active, err := activeDays(ctx, exec, t.ID.Int64)
+		if err != nil {
+			return nil, err
+		}
+		tr := rules.forTask(t)
+		label := fmt.Sprintf("%s (%s)", t.Name, tr.Schedule)
+		missed := tr.Schedule.overdue(active, tr.Excused, t.Start.Time, tr.WeekStart, now)
+		m := mins[strconv.FormatInt(t.ID.Int64, 10)]
+		var line string
+		switch {
+		case m > 0:
+			line = fmt.Sprintf("✓ %s  %d min", label, m)
+		case tr.Schedule.dueToday(active, tr.Excused, tr.WeekStart, now):
+			line = "○ " + label
+		case missed == 0:
+			continue
+		default:
+			line = "· " + label
+		}
+		// logging today catches up on whatever was missed
+		if missed > 0 && m == 0 {
+			line += fmt.Sprintf("  ! %s missed", plural(missed, "scheduled day"))
+			a.Overdue++
+		}
+		a.Tasks = append(a.Tasks, line)
+	}
+
+	if err := a.addReviews(ctx, exec, tasks, rules.WeekStart, now); err != nil {
+		return nil, err
+	}
+	if err := a.addMilestones(ctx, exec, tasks, rules, now); err != nil {
+		return nil, err
+	}
+
+	notes, err := models.Calendars(
+		qm.Where("date(date) = ?", day.Format(DateYMD)),
+		qm.OrderBy("id ASC"),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range notes {
+		a.Calendar = append(a.Calendar, fmt.Sprintf("%s: %s", n.Kind, n.Note))
+	}
+
+	// the coach message `coach check` would deliver next, without recording it
+	coach, err := loadCoachRules(ctx, exec, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tasks {
+		fired, err := fireCoach(ctx, exec, coach, t, rules, now)
+		if err != nil {
+			return nil, err
+		}
+		if len(fired) > 0 && !fired[0].Resting {
+			a.Coach = append(a.Coach, fmt.Sprintf("%s: %s", t.Name, fired[0].Content))
+		}
+	}
+	return a, nil
+}
+
+// addReviews lists seeded reviews up to each task's current week that are not done;
+// earlier weeks are overdue.
+func (a *agenda) addReviews(ctx context.Context, exec boil.ContextExecutor, tasks models.TaskSlice, weekStart time.Weekday, now time.Time) error {
+	for _, t := range tasks {
+		if !t.Start.Valid {
+			continue
+		}
+		cur := taskWeek(t.Start.Time, now, weekStart)
+		pending, err := models.Reviews(
+			qm.Where("task = ? AND reviewed IS NULL AND week <= ?", t.ID.Int64, cur),
+			qm.OrderBy("week ASC, id ASC"),
+		).All(ctx, exec)
+		if err != nil {
+			return err
+		}
+		for _, r := range pending {
+			line := fmt.Sprintf("%s week %d", t.Name, r.Week.Int64)
+			if r.Week.Int64 < cur {
+				line += "  ! overdue"
+				a.Overdue++
+			} else {
+				line += "  due this week"
+			}
+			a.Reviews = append(a.Reviews, line)
+		}
+	}
+	return nil
+}
+
+// addMilestones lists open milestones close to their target and those past their due date.
+func (a *agenda) addMilestones(ctx context.Context, exec boil.ContextExecutor, tasks models.TaskSlice, rules streakRules, now time.Time) error {
+	day := dateOnly(now)
+	for _, t := range tasks {
+		open, err := models.Milestones(
+			qm.Where("task = ? AND done IS NULL", t.ID.Int64),
+			qm.OrderBy("due IS NULL, due ASC, id ASC"),
+		).All(ctx, exec)
+		if err != nil {
+			return err
+		}
+		if len(open) == 0 {
+			continue
+		}
+		h, err := loadTaskHistory(ctx, exec, t, rules)
+		if err != nil {
+			return err
+		}
+		for _, m := range open {
+			label := m.Type.String
+			if label == "" {
+				label = m.Kind
+			}
+			line := fmt.Sprintf("%s %s", t.Name, label)
+			near := false
+			if m.Kind != milestoneManual && m.Value.Valid {
+				p := h.projectMilestone(m, now)
+				line += fmt.Sprintf("  %.0f%% of %s", 100*p.Frac(), milestoneUnit(m.Kind, p.Target))
+				near = p.Frac() >= todayMilestoneNear
+			}
+			late := m.Due.Valid && dateOnly(m.Due.Time).Before(day)
+			if late {
+				line += "  ! due " + m.Due.Time.Format(DateYMD)
+				a.Overdue++
+			}
+			if near || late {
+				a.Milestones = append(a.Milestones, line)
+			}
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (a *agenda) render(w io.Writer, now time.Time) {
+	fmt.Fprintf(w, "%s %s · %d min logged\n", now.Format("Mon"), now.Format(DateYMD), a.Logged)
+
+	section := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "\n%s\n", title)
+		for _, l := range lines {
+			fmt.Fprintf(w, "  %s\n", l)
+		}
+	}
+	section("Tasks", a.Tasks)
+	section("Reviews", a.Reviews)
+	section("Milestones", a.Milestones)
+	section("Calendar", a.Calendar)
+	section("Coach", a.Coach)
+
+	fmt.Fprintln(w)
+	switch {
+	case a.Overdue > 0:
+		fmt.Fprintf(w, "%s overdue.\n", plural(a.Overdue, "item"))
+	case len(a.Tasks) == 0:
+		fmt.Fprintln(w, "Nothing scheduled today.")
+	default:
+		fmt.Fprintln(w, "Nothing overdue.")
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -30,7 +30,6 @@ import (
 	"github.com/DanielRivasMD/horus"
 	"github.com/aarondl/null/v8"
 	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/spf13/cobra"
 	"github.com/ttacon/chalk"
@@ -61,20 +60,23 @@ const DateYMD = "2006-01-02"
 
 var (
 	verbose bool
-	dbPath  string // populated by the --db flag
+	dbPath  string // populated by the --db flag; read through resolveDBPath
 )
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func init() {
+	cobra.OnInitialize(initConfig)
+
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose diagnostics")
-	rootCmd.PersistentFlags().StringVar(&dbPath, "db", "sisu.db", "path to sqlite database")
+	rootCmd.PersistentFlags().StringVar(&dbPath, "db", "", "path to sqlite database (default $SISU_DB, config file, or $XDG_DATA_HOME/sisu/sisu.db)")
+	rootCmd.PersistentFlags().StringVar(&db.MigrationsDir, "migrations", "", "read migrations from this directory instead of the embedded set (development)")
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func dbPreRun(cmd *cobra.Command, args []string) {
-	if _, err := db.InitDB(dbPath); err != nil {
+	if _, err := db.InitDB(resolveDBPath()); err != nil {
 		log.Fatalf("init DB: %v", err)
 	}
 }
@@ -93,7 +95,7 @@ func EnsureDB() error {
 	if db.Conn != nil {
 		return nil
 	}
-	_, err := db.InitDB(dbPath)
+	_, err := db.InitDB(resolveDBPath())
 	return err
 }
 
@@ -104,7 +106,8 @@ func EnsureDB() error {
 // for TUI forms across commands, aligned with your current SQL schema.
 //
 // Schema highlights (Go types you’ll likely get from SQLBoiler):
-// - tasks:     Name string (required), Tag/Description null.String, Target/Start null.Time, Archived null.Bool or bool
+// - tasks:     Name string (required), Description null.String, Target/Start null.Time, Archived null.Bool or bool
+// - tags:      Name string (required, unique); tasks_tags joins tasks ↔ tags
 // - sessions:  Task int64, Date time.Time or null.Time (depends on NULL), Mins/Feedback null.Int64, Notes null.String
 // - milestones:Task int64, Type/Message null.String, Value null.Int64, Done time.Time or null.Time (depends on NULL)
 // - reviews:   Task int64, Week null.Int64, Summary null.String
@@ -193,6 +196,14 @@ func VDateOptional() func(string) error {
 
 // Null helpers → initial string for form fields
 
+// IDInitial leaves an unset foreign key blank rather than "0".
+func IDInitial(v int64) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatInt(v, 10)
+}
+
 func OptInt64Initial(v null.Int64) string {
 	if v.Valid {
 		return strconv.FormatInt(v.Int64, 10)
@@ -329,6 +340,8 @@ func Assign(field string, holder any, v any) {
 // FBool builds a boolean field (plain bool). If your model uses null.Bool, wrap with null.BoolFrom in Assign.
 func FBool(label, field, initial string) Field {
 	return Field{
+		Name:    flagName(field),
+		Help:    label,
 		Label:   chalk.Cyan.Color(label),
 		Initial: initial,
 		Parse:   ParseBool,
@@ -339,6 +352,8 @@ func FBool(label, field, initial string) Field {
 // FInt builds a required int64 field.
 func FInt(label, field, initial string, opts ...FieldOpt) Field {
 	f := Field{
+		Name:    flagName(field),
+		Help:    label,
 		Label:   chalk.Cyan.Color(label),
 		Initial: initial,
 		Parse:   ParseInt64,
@@ -353,6 +368,8 @@ func FInt(label, field, initial string, opts ...FieldOpt) Field {
 // FOptInt builds an optional int64 field (null.Int64).
 func FOptInt(label, field, initial string, opts ...FieldOpt) Field {
 	f := Field{
+		Name:    flagName(field),
+		Help:    label,
 		Label:   chalk.Cyan.Color(label),
 		Initial: initial,
 		Parse:   ParseOptInt64,
@@ -367,6 +384,8 @@ func FOptInt(label, field, initial string, opts ...FieldOpt) Field {
 // FDate builds a required date field (time.Time).
 func FDate(label, field, initial string, opts ...FieldOpt) Field {
 	f := Field{
+		Name:     flagName(field),
+		Help:     label,
 		Label:    chalk.Cyan.Color(label),
 		Initial:  initial,
 		Validate: VDate(label),
@@ -382,6 +401,8 @@ func FDate(label, field, initial string, opts ...FieldOpt) Field {
 // FOptDate builds an optional date field (null.Time).
 func FOptDate(label, field, initial string, opts ...FieldOpt) Field {
 	f := Field{
+		Name:     flagName(field),
+		Help:     label,
 		Label:    chalk.Cyan.Color(label),
 		Initial:  initial,
 		Validate: VDateOptional(),
@@ -397,6 +418,8 @@ func FOptDate(label, field, initial string, opts ...FieldOpt) Field {
 // FString builds a required non-empty string field.
 func FString(label, field, initial string, opts ...FieldOpt) Field {
 	f := Field{
+		Name:     flagName(field),
+		Help:     label,
 		Label:    chalk.Cyan.Color(label),
 		Initial:  initial,
 		Validate: VRequired(label),
@@ -412,6 +435,8 @@ func FString(label, field, initial string, opts ...FieldOpt) Field {
 // FOptString remains optional; add opts for consistency.
 func FOptString(label, field, initial string, opts ...FieldOpt) Field {
 	f := Field{
+		Name:    flagName(field),
+		Help:    label,
 		Label:   chalk.Cyan.Color(label),
 		Initial: initial,
 		Parse:   ParseOptString,
@@ -432,15 +457,29 @@ func WithValidate(v func(string) error) FieldOpt     { return func(f *Field) { f
 func WithParse(p func(string) (any, error)) FieldOpt { return func(f *Field) { f.Parse = p } }
 func WithAssign(a func(any, any)) FieldOpt           { return func(f *Field) { f.Assign = a } }
 func WithLabel(lbl string) FieldOpt                  { return func(f *Field) { f.Label = lbl } }
+func WithName(name string) FieldOpt                  { return func(f *Field) { f.Name = name } }
+
+// flagName turns a model field name into its flag, e.g. "PausedSecs" → "paused-secs".
+func flagName(field string) string {
+	var b strings.Builder
+	for i, r := range field {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('-')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func FChoice(label string, initial string, allow []string, setter func(string)) Field {
+func FChoice(label string, initial string, allow []string, setter func(string), opts ...FieldOpt) Field {
 	lowerSet := make(map[string]struct{}, len(allow))
 	for _, v := range allow {
 		lowerSet[strings.ToLower(v)] = struct{}{}
 	}
-	return Field{
+	f := Field{
+		Help:     label,
 		Label:    label,
 		Initial:  initial,
 		Validate: VRequired(label),
@@ -453,6 +492,10 @@ func FChoice(label string, initial string, allow []string, setter func(string))
 		},
 		Assign: func(_ any, v any) { setter(v.(string)) },
 	}
+	for _, opt := range opts {
+		opt(&f)
+	}
+	return f
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -23,11 +23,13 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"os"
 	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 
 	"github.com/DanielRivasMD/Sisu/db"
@@ -44,16 +46,21 @@ type CrudModel[T any] struct {
 	TableHeaders []string
 	TableRow     func(item T) []string
 	HintFn       func(item T) string
+	ListFlags    func(list *cobra.Command) // optional; register filters read by ListFn
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func RegisterCrudSubcommands[T any](
 	parent *cobra.Command,
-	dbPath string,
+	dbPath func() string,
 	desc CrudModel[T],
 ) {
-	parent.PersistentPreRun = dbPreRun
+	parent.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		if _, err := db.InitDB(dbPath()); err != nil {
+			log.Fatalf("init DB: %v", err)
+		}
+	}
 	parent.PersistentPostRun = dbPostRun
 
 	// list
@@ -84,6 +91,9 @@ func RegisterCrudSubcommands[T any](
 			}
 		},
 	}
+	if desc.ListFlags != nil {
+		desc.ListFlags(list)
+	}
 	parent.AddCommand(list)
 
 	// rm
@@ -254,7 +264,8 @@ func RenderTable(headers []string, rows [][]string) string {
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 type Field struct {
-	Name     string
+	Name     string // flag name; the F* builders derive it from the model field
+	Help     string // plain-text label used as flag usage
 	Label    string
 	Initial  string
 	Validate func(input string) error
@@ -298,23 +309,11 @@ func (m FormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	f.Input = ti
 
 	if key, ok := msg.(tea.KeyMsg); ok && key.String() == "enter" {
-		raw := f.Input.Value()
-
-		if f.Validate != nil {
-			if err := f.Validate(raw); err != nil {
-				f.err = err
-				return m, nil
-			}
-		}
-
-		v, err := f.Parse(raw)
-		if err != nil {
+		if err := applyField(f, m.holder, f.Input.Value()); err != nil {
 			f.err = err
 			return m, nil
 		}
 
-		f.Assign(m.holder, v)
-
 		f.err = nil
 		m.idx++
 		if m.idx >= len(m.fields) {
@@ -362,3 +361,104 @@ func RunFormWizardWithSubmit(fields []Field, holder any, onSubmit func(holder an
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// applyField validates, parses and assigns one raw value.
+func applyField(f *Field, holder any, raw string) error {
+	if f.Validate != nil {
+		if err := f.Validate(raw); err != nil {
+			return err
+		}
+	}
+	v, err := f.Parse(raw)
+	if err != nil {
+		return err
+	}
+	f.Assign(holder, v)
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// BindFieldFlags registers one string flag per named field, so every form
+// can also be filled from the command line. Call it from init() with the
+// same field builder the command runs.
+func BindFieldFlags(cmd *cobra.Command, fields []Field) {
+	for _, f := range fields {
+		if f.Name == "" || cmd.Flags().Lookup(f.Name) != nil {
+			continue
+		}
+		cmd.Flags().String(f.Name, "", f.Help)
+	}
+}
+
+// RunForm fills holder from flags, then field initials, and prompts only for what is left:
+// each field takes its flag, else its initial value; a value that still fails validation
+// is prompted for on a TTY and is fatal elsewhere.
+//
+// Fields are applied in order so parsers may depend on earlier assignments.
+func RunForm(cmd *cobra.Command, fields []Field, holder any) {
+	interactive := stdinIsTTY()
+	for i := range fields {
+		f := &fields[i]
+		raw, fromFlag := f.Initial, false
+		if f.Name != "" && cmd.Flags().Changed(f.Name) {
+			raw, _ = cmd.Flags().GetString(f.Name)
+			fromFlag = true
+		}
+
+		err := applyField(f, holder, raw)
+		switch {
+		case err == nil:
+			continue
+		case fromFlag:
+			log.Fatalf("--%s: %v", f.Name, err)
+		case !interactive && strings.TrimSpace(raw) == "":
+			log.Fatalf("missing required --%s (%s)", f.Name, f.Help)
+		case !interactive:
+			log.Fatalf("--%s %q: %v", f.Name, raw, err)
+		}
+
+		f.Initial = raw
+		RunFormWizard([]Field{*f}, holder)
+	}
+}
+
+// RunEditForm is RunForm for records that already hold valid values: with no field
+// flags on a TTY nothing would change, so the wizard walks every field instead.
+func RunEditForm(cmd *cobra.Command, fields []Field, holder any) {
+	if stdinIsTTY() && !fieldFlagsChanged(cmd, fields) {
+		RunFormWizard(fields, holder)
+		return
+	}
+	RunForm(cmd, fields, holder)
+}
+
+// confirm asks a yes/no question through the form wizard; off a TTY it returns def.
+func confirm(question string, def bool) bool {
+	if !stdinIsTTY() {
+		return def
+	}
+	initial := "n"
+	if def {
+		initial = "y"
+	}
+	var answer struct{ Yes bool }
+	RunFormWizard([]Field{FBool(question+" (y/n)", "Yes", initial)}, &answer)
+	return answer.Yes
+}
+
+func fieldFlagsChanged(cmd *cobra.Command, fields []Field) bool {
+	for _, f := range fields {
+		if f.Name != "" && cmd.Flags().Changed(f.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// stdinIsTTY reports whether stdin is an interactive terminal.
+func stdinIsTTY() bool {
+	return isatty.IsTerminal(os.Stdin.Fd())
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
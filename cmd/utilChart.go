@@ -0,0 +1,316 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Charts take NaN as "no value": bars and sparks leave a gap, lines skip the point.
+
+var (
+	sparkRunes = []rune("▁▂▃▄▅▆▇█")
+	barEighths = []rune(" ▏▎▍▌▋▊▉")
+)
+
+// seriesRange returns the min and max of the non-NaN values; ok is false when there are none.
+func seriesRange(vals []float64) (lo, hi float64, ok bool) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, v := range vals {
+		if math.IsNaN(v) {
+			continue
+		}
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+		ok = true
+	}
+	return lo, hi, ok
+}
+
+// sparkline renders one rune per value, scaled from zero (or the minimum, if negative) to the max.
+func sparkline(vals []float64) string {
+	lo, hi, ok := seriesRange(vals)
+	if !ok {
+		return strings.Repeat(" ", len(vals))
+	}
+	lo = math.Min(lo, 0)
+	var b strings.Builder
+	for _, v := range vals {
+		if math.IsNaN(v) {
+			b.WriteRune(' ')
+			continue
+		}
+		i := 0
+		if hi > lo {
+			i = int(math.Round((v - lo) / (hi - lo) * float64(len(sparkRunes)-1)))
+		}
+		b.WriteRune(sparkRunes[i])
+	}
+	return b.String()
+}
+
+// barChart draws one horizontal bar per label, sized to fit width columns.
+func barChart(labels []string, vals []float64, width int, color bool, format func(float64) string) string {
+	_, hi, ok := seriesRange(vals)
+	labelW, valueW := 0, 0
+	for i, l := range labels {
+		labelW = max(labelW, utf8.RuneCountInString(l))
+		if !math.IsNaN(vals[i]) {
+			valueW = max(valueW, len(format(vals[i])))
+		}
+	}
+	barW := width - labelW - valueW - 4
+	if barW < 4 {
+		barW = 4
+	}
+
+	var b strings.Builder
+	for i, l := range labels {
+		bar, value := "", ""
+		if v := vals[i]; ok && hi > 0 && !math.IsNaN(v) {
+			bar, value = eighthBar(v/hi, barW), format(v)
+		}
+		pad := strings.Repeat(" ", barW-utf8.RuneCountInString(bar))
+		if color && bar != "" {
+			bar = paint(ansiGreen, bar)
+		}
+		fmt.Fprintf(&b, "%-*s │%s%s %s\n", labelW, l, bar, pad, value)
+	}
+	return b.String()
+}
+
+// eighthBar fills frac of width cells with eighth-block precision.
+func eighthBar(frac float64, width int) string {
+	frac = math.Max(0, math.Min(1, frac))
+	eighths := int(math.Round(frac * float64(width*8)))
+	s := strings.Repeat("█", eighths/8)
+	if r := eighths % 8; r > 0 {
+		s += string(barEighths[r])
+	}
+	return s
+}
+
+// lineChart plots vals on a braille canvas of width x height cells (2x4 dots each),
+// with the max and min on a left axis and first/last labels underneath.
+func lineChart(vals []float64, first, last string, width, height int, color bool, format func(float64) string) string {
+	lo, hi, ok := seriesRange(vals)
+	if !ok {
+		return "no data\n"
+	}
+	if hi == lo {
+		hi, lo = hi+1, math.Min(lo, 0)
+	}
+	axisW := max(len(format(hi)), len(format(lo)))
+	cols := width - axisW - 2
+	if cols < 4 {
+		cols = 4
+	}
+	c := newBraille(cols, height)
+
+	dotsX, dotsY := cols*2-1, height*4-1
+	px := func(i int) int {
+		if len(vals) == 1 {
+			return 0
+		}
+		return int(math.Round(float64(i) / float64(len(vals)-1) * float64(dotsX)))
+	}
+	py := func(v float64) int {
+		return dotsY - int(math.Round((v-lo)/(hi-lo)*float64(dotsY)))
+	}
+
+	prev := -1
+	for i, v := range vals {
+		if math.IsNaN(v) {
+			continue
+		}
+		if prev >= 0 {
+			c.line(px(prev), py(vals[prev]), px(i), py(v))
+		} else {
+			c.set(px(i), py(v))
+		}
+		prev = i
+	}
+
+	var b strings.Builder
+	for r, row := range c.rows() {
+		label := ""
+		switch r {
+		case 0:
+			label = format(hi)
+		case height - 1:
+			label = format(lo)
+		}
+		if color {
+			row = paint(ansiGreen, row)
+		}
+		fmt.Fprintf(&b, "%*s ┤%s\n", axisW, label, row)
+	}
+	gap := cols - utf8.RuneCountInString(first) - utf8.RuneCountInString(last)
+	fmt.Fprintf(&b, "%*s  %s%s%s\n", axisW, "", first, strings.Repeat(" ", max(gap, 1)), last)
+	return b.String()
+}
+
+// chartSeries is one series of a markerChart, drawn with Marker in SGR Color (0 for none).
+type chartSeries struct {
+	Vals   []float64
+	Marker rune
+	Color  int
+}
+
+// markerChart plots series sharing one x axis on a width x height grid, one marker per
+// column, scaled from zero to the max; later series draw over earlier ones.
+func markerChart(series []chartSeries, first, last string, width, height int, color bool, format func(float64) string) string {
+	var all []float64
+	n := 0
+	for _, s := range series {
+		all = append(all, s.Vals...)
+		n = max(n, len(s.Vals))
+	}
+	_, hi, ok := seriesRange(all)
+	if !ok {
+		return "no data\n"
+	}
+	if hi <= 0 {
+		hi = 1
+	}
+	axisW := max(len(format(hi)), len(format(0)))
+	cols := width - axisW - 2
+	if cols < 4 {
+		cols = 4
+	}
+
+	grid := make([][]string, height)
+	for r := range grid {
+		grid[r] = make([]string, cols)
+		for c := range grid[r] {
+			grid[r][c] = " "
+		}
+	}
+	for _, s := range series {
+		for c := 0; c < cols; c++ {
+			i := 0
+			if cols > 1 {
+				i = int(math.Round(float64(c) / float64(cols-1) * float64(n-1)))
+			}
+			if i >= len(s.Vals) || math.IsNaN(s.Vals[i]) {
+				continue
+			}
+			r := height - 1 - int(math.Round(math.Max(0, s.Vals[i])/hi*float64(height-1)))
+			cell := string(s.Marker)
+			if color && s.Color != 0 {
+				cell = paint(s.Color, cell)
+			}
+			grid[r][c] = cell
+		}
+	}
+
+	var b strings.Builder
+	for r, row := range grid {
+		label := ""
+		switch r {
+		case 0:
+			label = format(hi)
+		case height - 1:
+			label = format(0)
+		}
+		fmt.Fprintf(&b, "%*s ┤%s\n", axisW, label, strings.Join(row, ""))
+	}
+	gap := cols - utf8.RuneCountInString(first) - utf8.RuneCountInString(last)
+	fmt.Fprintf(&b, "%*s  %s%s%s\n", axisW, "", first, strings.Repeat(" ", max(gap, 1)), last)
+	return b.String()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// braille is a dot canvas; each cell packs 2 columns by 4 rows of dots.
+type braille struct {
+	w, h  int
+	cells [][]rune
+}
+
+// dot bits for (x%2, y%4) within a braille cell
+var brailleBits = [2][4]rune{{0x01, 0x02, 0x04, 0x40}, {0x08, 0x10, 0x20, 0x80}}
+
+func newBraille(w, h int) *braille {
+	cells := make([][]rune, h)
+	for i := range cells {
+		cells[i] = make([]rune, w)
+	}
+	return &braille{w: w, h: h, cells: cells}
+}
+
+func (c *braille) set(x, y int) {
+	if x < 0 || y < 0 || x >= c.w*2 || y >= c.h*4 {
+		return
+	}
+	c.cells[y/4][x/2] |= brailleBits[x%2][y%4]
+}
+
+// line draws with Bresenham's algorithm.
+func (c *braille) line(x0, y0, x1, y1 int) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		c.set(x0, y0)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+func (c *braille) rows() []string {
+	out := make([]string, c.h)
+	for i, row := range c.cells {
+		var b strings.Builder
+		for _, bits := range row {
+			b.WriteRune(0x2800 + bits)
+		}
+		out[i] = b.String()
+	}
+	return out
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
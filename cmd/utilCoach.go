@@ -0,0 +1,382 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// taskRuleVars computes the rule variables for one task as of now.
+func taskRuleVars(ctx context.Context, exec boil.ContextExecutor, t *models.Task, rules streakRules, now time.Time) (map[string]float64, error) {
+	day := dateOnly(now)
+	id := t.ID.Int64
+	rules = rules.forTask(t)
+
+	window := func(days int) (sessionAgg, error) {
+		aggs, err := aggregateSessions(ctx, exec, bucketTask, sessionFilter{
+			Task: id,
+			From: day.AddDate(0, 0, 1-days),
+			To:   day,
+		})
+		if err != nil || len(aggs) == 0 {
+			return sessionAgg{}, err
+		}
+		return aggs[0], nil
+	}
+	feedback := func(a sessionAgg) float64 {
+		if a.Feedback.Valid {
+			return a.Feedback.Float64
+		}
+		return math.NaN()
+	}
+
+	w1, err := window(1)
+	if err != nil {
+		return nil, err
+	}
+	w7, err := window(7)
+	if err != nil {
+		return nil, err
+	}
+	w30, err := window(30)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := activeDays(ctx, exec, id)
+	if err != nil {
+		return nil, err
+	}
+	streaks := computeStreaks(active, rules, now)
+	adherence := func(days int) float64 {
+		from := day.AddDate(0, 0, 1-days)
+		if t.Start.Valid && dateOnly(t.Start.Time).After(from) {
+			from = dateOnly(t.Start.Time)
+		}
+		kept, slots := rules.Schedule.adherence(active, rules.Excused, from, day, rules.WeekStart, now)
+		if slots == 0 {
+			return math.NaN()
+		}
+		return float64(kept) / float64(slots)
+	}
+	dueToday := 0.0
+	if rules.Schedule.dueToday(active, rules.Excused, rules.WeekStart, now) {
+		dueToday = 1
+	}
+
+	vars := map[string]float64{
+		"sessions_7d":      float64(w7.Sessions),
+		"sessions_30d":     float64(w30.Sessions),
+		"mins_today":       float64(w1.Total),
+		"mins_7d":          float64(w7.Total),
+		"mins_30d":         float64(w30.Total),
+		"active_days_7d":   float64(w7.Days),
+		"avg_feedback_7d":  feedback(w7),
+		"avg_feedback_30d": feedback(w30),
+		"streak":           float64(streaks.Current.Days),
+		"longest_streak":   float64(streaks.Longest.Days),
+		"adherence_7d":     adherence(7),
+		"adherence_30d":    adherence(30),
+		"due_today":        dueToday,
+		"days_overdue":     float64(rules.Schedule.overdue(active, rules.Excused, t.Start.Time, rules.WeekStart, now)),
+		"days_to_target":   math.NaN(),
+		"task_days":        math.NaN(),
+		"task_week":        math.NaN(),
+	}
+
+	var last time.Time
+	for d := range active {
+		if d.After(last) && !d.After(day) {
+			last = d
+		}
+	}
+	switch {
+	case !last.IsZero():
+		vars["days_since_last_session"] = float64(daysBetween(last, day))
+	case t.Start.Valid:
+		vars["days_since_last_session"] = float64(max(daysBetween(dateOnly(t.Start.Time), day), 0))
+	default:
+		vars["days_since_last_session"] = math.NaN()
+	}
+
+	if t.Target.Valid {
+		vars["days_to_target"] = float64(daysBetween(day, dateOnly(t.Target.Time)))
+	}
+	if t.Start.Valid {
+		vars["task_days"] = float64(daysBetween(dateOnly(t.Start.Time), day))
+		vars["task_week"] = float64(taskWeek(t.Start.Time, day, rules.WeekStart))
+	}
+	return vars, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// coachData is what coach content templates see, e.g. "{{.Task.Name}}: {{.Streak}} days".
+// Missing values are zero: no target, no scored sessions, no last session.
+type coachData struct {
+	Task          coachTask
+	Streak        int
+	LongestStreak int
+	DaysToTarget  int
+	AvgFeedback   float64 // last 7 days
+	MinsToday     int64
+	Mins7d        int64
+	LastSession   coachSession
+	Vars          map[string]float64 // every rule variable, for {{index .Vars "mins_30d"}}
+}
+
+type coachTask struct {
+	ID          int64
+	Name        string
+	Description string
+	Start       string
+	Target      string
+}
+
+type coachSession struct {
+	Date     string
+	Mins     int64
+	Feedback int64
+	Class    string
+	Notes    string
+}
+
+var coachFuncs = template.FuncMap{
+	"plural": plural,
+}
+
+// coachDataFor gathers a task's template data from the same stats rules evaluate.
+func coachDataFor(ctx context.Context, exec boil.ContextExecutor, t *models.Task, rules streakRules, now time.Time) (coachData, error) {
+	vars, err := taskRuleVars(ctx, exec, t, rules, now)
+	if err != nil {
+		return coachData{}, err
+	}
+	num := func(name string) float64 {
+		if v := vars[name]; !math.IsNaN(v) {
+			return v
+		}
+		return 0
+	}
+
+	data := coachData{
+		Task: coachTask{
+			ID:          t.ID.Int64,
+			Name:        t.Name,
+			Description: t.Description.String,
+		},
+		Streak:        int(num("streak")),
+		LongestStreak: int(num("longest_streak")),
+		DaysToTarget:  int(num("days_to_target")),
+		AvgFeedback:   math.Round(num("avg_feedback_7d")*10) / 10,
+		MinsToday:     int64(num("mins_today")),
+		Mins7d:        int64(num("mins_7d")),
+		Vars:          vars,
+	}
+	if t.Start.Valid {
+		data.Task.Start = t.Start.Time.Format(DateYMD)
+	}
+	if t.Target.Valid {
+		data.Task.Target = t.Target.Time.Format(DateYMD)
+	}
+
+	last, err := models.Sessions(
+		qm.Where("task = ?", t.ID.Int64),
+		qm.Where("date IS NOT NULL"),
+		qm.OrderBy("date DESC, id DESC"),
+	).One(ctx, exec)
+	switch {
+	case err == nil:
+		data.LastSession = coachSession{
+			Date:     last.Date.Time.Format(DateYMD),
+			Mins:     last.Mins.Int64,
+			Feedback: last.Feedback.Int64,
+			Class:    last.Class.String,
+			Notes:    last.Notes.String,
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return coachData{}, err
+	}
+	return data, nil
+}
+
+// parseCoachContent compiles content as a template; unknown fields are caught by
+// executing it once against empty data.
+func parseCoachContent(content string) (*template.Template, error) {
+	tmpl, err := template.New("coach").Funcs(coachFuncs).Option("missingkey=zero").Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpl.Execute(io.Discard, coachData{}); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// renderCoach executes coach content against a task's data.
+func renderCoach(content string, data coachData) (string, error) {
+	tmpl, err := parseCoachContent(content)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// VCoachContent validates coach content as a template.
+func VCoachContent(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return errors.New("content is required")
+	}
+	_, err := parseCoachContent(s)
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// coachRule is a coach entry with its compiled trigger.
+type coachRule struct {
+	Entry *models.Coach
+	Rule  rule
+}
+
+// loadCoachRules compiles every coach trigger, reporting and skipping invalid ones.
+func loadCoachRules(ctx context.Context, exec boil.ContextExecutor, warn io.Writer) ([]coachRule, error) {
+	entries, err := models.Coaches(qm.OrderBy("id ASC")).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	var rules []coachRule
+	for _, c := range entries {
+		r, err := parseRule(c.Trigger)
+		if err != nil {
+			fmt.Fprintf(warn, "coach %d: skipping trigger %q: %v\n", c.ID.Int64, c.Trigger, err)
+			continue
+		}
+		rules = append(rules, coachRule{c, r})
+	}
+	return rules, nil
+}
+
+// firedCoach is a rule that fires for a task, rendered against its data.
+type firedCoach struct {
+	Entry   *models.Coach
+	Content string
+	Last    time.Time // last delivery to the task; zero if never
+	Resting bool      // delivered within its cooldown
+}
+
+// fireCoach evaluates the rules scoped to t and returns the ones that fire in
+// rotation order: deliverable before resting, then least recently delivered first.
+func fireCoach(ctx context.Context, exec boil.ContextExecutor, rules []coachRule, t *models.Task, sr streakRules, now time.Time) ([]firedCoach, error) {
+	vars, err := taskRuleVars(ctx, exec, t, sr, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var fired []firedCoach
+	for _, cr := range rules {
+		if cr.Entry.Task.Valid && cr.Entry.Task.Int64 != t.ID.Int64 {
+			continue
+		}
+		if ruleFires(cr.Rule, vars) {
+			fired = append(fired, firedCoach{Entry: cr.Entry})
+		}
+	}
+	if len(fired) == 0 {
+		return nil, nil
+	}
+
+	data, err := coachDataFor(ctx, exec, t, sr, now)
+	if err != nil {
+		return nil, err
+	}
+	last, err := lastDeliveries(ctx, exec, t.ID.Int64)
+	if err != nil {
+		return nil, err
+	}
+	day := dateOnly(now)
+	for i := range fired {
+		f := &fired[i]
+		if f.Content, err = renderCoach(f.Entry.Content, data); err != nil {
+			f.Content = fmt.Sprintf("(template error: %v)", err)
+		}
+		f.Last = last[f.Entry.ID.Int64]
+		f.Resting = !f.Last.IsZero() && f.Entry.Cooldown > 0 &&
+			daysBetween(f.Last, day) < int(f.Entry.Cooldown)
+	}
+
+	sort.SliceStable(fired, func(i, j int) bool {
+		a, b := fired[i], fired[j]
+		if a.Resting != b.Resting {
+			return !a.Resting
+		}
+		return a.Last.Before(b.Last)
+	})
+	return fired, nil
+}
+
+// lastDeliveries maps coach IDs to their latest delivery to a task.
+func lastDeliveries(ctx context.Context, exec boil.ContextExecutor, taskID int64) (map[int64]time.Time, error) {
+	rows, err := models.CoachDeliveries(
+		qm.Where("task = ?", taskID),
+		qm.OrderBy("delivered DESC"),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	last := make(map[int64]time.Time, len(rows))
+	for _, d := range rows {
+		if _, ok := last[d.Coach]; !ok {
+			last[d.Coach] = d.Delivered.Local()
+		}
+	}
+	return last, nil
+}
+
+// deliverCoach logs that a fired message was shown for a task.
+func deliverCoach(ctx context.Context, exec boil.ContextExecutor, taskID int64, f firedCoach, now time.Time) error {
+	d := &models.CoachDelivery{
+		Coach:     f.Entry.ID.Int64,
+		Task:      taskID,
+		Delivered: now,
+		Content:   f.Content,
+	}
+	return d.Insert(ctx, exec, boil.Infer())
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,135 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Database path resolution, highest priority first:
+//   1. --db flag
+//   2. $SISU_DB
+//   3. `db = "..."` in ~/.config/sisu/config.toml
+//   4. $XDG_DATA_HOME/sisu/sisu.db (~/.local/share/sisu/sisu.db)
+
+const (
+	appName    = "sisu"
+	configName = "config.toml"
+	envPrefix  = "SISU"
+	keyDB      = "db"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// initConfig wires viper to the config file, environment and --db flag.
+func initConfig() {
+	viper.SetConfigFile(configFilePath())
+	viper.SetConfigType("toml")
+	viper.SetEnvPrefix(envPrefix)
+	viper.SetDefault(keyDB, defaultDBPath())
+
+	if err := viper.BindEnv(keyDB); err != nil {
+		log.Fatalf("bind env: %v", err)
+	}
+	if err := viper.BindPFlag(keyDB, rootCmd.PersistentFlags().Lookup(keyDB)); err != nil {
+		log.Fatalf("bind flag: %v", err)
+	}
+
+	if err := viper.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFound) && !errors.Is(err, os.ErrNotExist) {
+			log.Fatalf("read config %s: %v", configFilePath(), err)
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// configDir honors $XDG_CONFIG_HOME, falling back to ~/.config/sisu.
+func configDir() string {
+	if x := os.Getenv("XDG_CONFIG_HOME"); x != "" {
+		return filepath.Join(x, appName)
+	}
+	return filepath.Join(homeDir(), ".config", appName)
+}
+
+func configFilePath() string {
+	return filepath.Join(configDir(), configName)
+}
+
+// defaultDBPath honors $XDG_DATA_HOME, falling back to ~/.local/share/sisu/sisu.db.
+func defaultDBPath() string {
+	if x := os.Getenv("XDG_DATA_HOME"); x != "" {
+		return filepath.Join(x, appName, "sisu.db")
+	}
+	return filepath.Join(homeDir(), ".local", "share", appName, "sisu.db")
+}
+
+func homeDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		log.Fatalf("locate home directory: %v", err)
+	}
+	return home
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// resolveDBPath returns the database file to open and makes sure its directory exists.
+func resolveDBPath() string {
+	path := expandHome(viper.GetString(keyDB))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		log.Fatalf("create database directory: %v", err)
+	}
+	return path
+}
+
+// dbPathSource names the layer that supplied the database path.
+func dbPathSource() string {
+	switch {
+	case rootCmd.PersistentFlags().Changed(keyDB):
+		return "--db flag"
+	case os.Getenv(envPrefix+"_DB") != "":
+		return "$" + envPrefix + "_DB"
+	case viper.InConfig(keyDB):
+		return "config file"
+	default:
+		return "default"
+	}
+}
+
+func expandHome(path string) string {
+	if path == "~" {
+		return homeDir()
+	}
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(homeDir(), path[2:])
+	}
+	return path
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
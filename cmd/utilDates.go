@@ -0,0 +1,187 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Date math works on calendar days: dates parsed with DateYMD are UTC midnight
+// while time.Now() is local, so both are reduced to their Y-M-D first.
+
+// dateOnly returns t's calendar date as UTC midnight.
+func dateOnly(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// today returns the local calendar date as UTC midnight.
+func today() time.Time {
+	return dateOnly(time.Now())
+}
+
+// daysBetween counts calendar days from a to b (negative when b is earlier).
+func daysBetween(a, b time.Time) int {
+	return int(dateOnly(b).Sub(dateOnly(a)).Hours() / 24)
+}
+
+// startOfWeek returns the most recent weekStart on or before t.
+func startOfWeek(t time.Time, weekStart time.Weekday) time.Time {
+	d := dateOnly(t)
+	offset := (int(d.Weekday()) - int(weekStart) + 7) % 7
+	return d.AddDate(0, 0, -offset)
+}
+
+// taskWeek is the 1-based week of at relative to a task's start,
+// with week boundaries falling on weekStart.
+func taskWeek(start, at time.Time, weekStart time.Weekday) int64 {
+	days := daysBetween(startOfWeek(start, weekStart), startOfWeek(at, weekStart))
+	return int64(days/7) + 1
+}
+
+// parseDuration accepts Go durations ("1h15m", "1.5h") plus the forms people
+// type: bare minutes ("90"), trailing minutes ("1h30"), clock ("1:30") and
+// spelled-out units ("1 hr 30 min").
+func parseDuration(s string) (time.Duration, error) {
+	in := strings.ToLower(strings.Join(strings.Fields(s), ""))
+	if in == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	invalid := fmt.Errorf("invalid duration %q (try 45m, 1h15m, 1h30, 1.5h)", s)
+	if n, err := strconv.Atoi(in); err == nil {
+		if n <= 0 {
+			return 0, invalid
+		}
+		return time.Duration(n) * time.Minute, nil
+	}
+	if h, m, ok := strings.Cut(in, ":"); ok {
+		hh, err1 := strconv.Atoi(h)
+		mm, err2 := strconv.Atoi(m)
+		d := time.Duration(hh)*time.Hour + time.Duration(mm)*time.Minute
+		if err1 != nil || err2 != nil || hh < 0 || mm < 0 || mm > 59 || d <= 0 {
+			return 0, invalid
+		}
+		return d, nil
+	}
+
+	in = durationUnits.Replace(in)
+	if last := in[len(in)-1]; last >= '0' && last <= '9' && strings.Contains(in, "h") {
+		// trailing minutes after hours, as on a clock: 1h30 but not 1h70
+		if mm, err := strconv.Atoi(in[strings.LastIndex(in, "h")+1:]); err != nil || mm > 59 {
+			return 0, invalid
+		}
+		in += "m"
+	}
+	d, err := time.ParseDuration(in)
+	if err != nil || d <= 0 {
+		return 0, invalid
+	}
+	return d, nil
+}
+
+// durationUnits folds spelled-out units into Go's; longer names come first.
+var durationUnits = strings.NewReplacer(
+	"hours", "h", "hour", "h", "hrs", "h", "hr", "h",
+	"minutes", "m", "minute", "m", "mins", "m", "min", "m",
+)
+
+// parseRelativeDate resolves YYYY-MM-DD, "today", "yesterday", "-Nd" / "-Nw",
+// and weekday names ("mon" is today or the latest Monday, "last mon" the one before today).
+func parseRelativeDate(s string, now time.Time) (time.Time, error) {
+	in := strings.ToLower(strings.TrimSpace(s))
+	base := dateOnly(now)
+
+	switch in {
+	case "", "today":
+		return base, nil
+	case "yesterday":
+		return base.AddDate(0, 0, -1), nil
+	}
+
+	if t, err := time.Parse(DateYMD, in); err == nil {
+		return t, nil
+	}
+
+	if strings.HasPrefix(in, "-") && len(in) > 2 {
+		n, err := strconv.Atoi(in[1 : len(in)-1])
+		if err == nil && n >= 0 {
+			switch in[len(in)-1] {
+			case 'd':
+				return base.AddDate(0, 0, -n), nil
+			case 'w':
+				return base.AddDate(0, 0, -7*n), nil
+			}
+		}
+	}
+
+	day, last := in, false
+	if rest, ok := strings.CutPrefix(in, "last "); ok {
+		day, last = strings.TrimSpace(rest), true
+	}
+	if wd, err := parseWeekday(day); err == nil {
+		back := (int(base.Weekday()) - int(wd) + 7) % 7
+		if last && back == 0 {
+			back = 7
+		}
+		return base.AddDate(0, 0, -back), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q (use YYYY-MM-DD, today, yesterday, -3d, last mon)", s)
+}
+
+// formatClock renders a duration as H:MM:SS.
+func formatClock(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	s := int64(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// parseWeekday accepts short or long English day names, case-insensitive.
+func parseWeekday(s string) (time.Weekday, error) {
+	if d, ok := weekdayNames[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid weekday %q (use mon, tue, ... sun)", s)
+}
+
+// weekdayShort renders a weekday as its three-letter lowercase name.
+func weekdayShort(d time.Weekday) string {
+	return strings.ToLower(d.String()[:3])
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,102 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"testing"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"90m", 90 * time.Minute},
+		{"90", 90 * time.Minute},
+		{"1h30", 90 * time.Minute},
+		{"1h05", 65 * time.Minute},
+		{"1.5h", 90 * time.Minute},
+		{"1h15m", 75 * time.Minute},
+		{"1:30", 90 * time.Minute},
+		{"0:45", 45 * time.Minute},
+		{"1 hr 30 min", 90 * time.Minute},
+		{"45 Mins", 45 * time.Minute},
+		{"2hours", 2 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := parseDuration(tt.in)
+		if err != nil {
+			t.Errorf("parseDuration(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseDuration(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "0", "-20", "0m", "-5m", "1h70", "1:75", "0:00", "-1:30", "abc", "1x"} {
+		if got, err := parseDuration(in); err == nil {
+			t.Errorf("parseDuration(%q) = %v, want error", in, got)
+		}
+	}
+}
+
+func TestParseRelativeDate(t *testing.T) {
+	// saturday afternoon; only the date counts
+	now := time.Date(2026, 10, 17, 15, 4, 0, 0, time.UTC)
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", "2026-10-17"},
+		{"today", "2026-10-17"},
+		{"Yesterday", "2026-10-16"},
+		{"-3d", "2026-10-14"},
+		{"-0d", "2026-10-17"},
+		{"-2w", "2026-10-03"},
+		{"2026-10-01", "2026-10-01"},
+		{"sat", "2026-10-17"},
+		{"last sat", "2026-10-10"},
+		{"mon", "2026-10-12"},
+		{"last mon", "2026-10-12"},
+		{"last  Friday", "2026-10-16"},
+	}
+	for _, tt := range tests {
+		got, err := parseRelativeDate(tt.in, now)
+		if err != nil {
+			t.Errorf("parseRelativeDate(%q): %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(ymd(tt.want)) {
+			t.Errorf("parseRelativeDate(%q) = %s, want %s", tt.in, got.Format(DateYMD), tt.want)
+		}
+	}
+
+	for _, in := range []string{"tomorrow", "-3x", "-d", "last", "2026-13-01", "next mon"} {
+		if got, err := parseRelativeDate(in, now); err == nil {
+			t.Errorf("parseRelativeDate(%q) = %s, want error", in, got.Format(DateYMD))
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -59,16 +59,175 @@ var exampleRoot = formatExample(
 var exampleMigrate = formatExample(
 	"sisu",
 	[]string{"migrate"},
+	[]string{"migrate", "status"},
+	[]string{"migrate", "down", "1"},
+	[]string{"migrate", "goto", "1"},
+	[]string{"migrate", "force", "1"},
+)
+
+var exampleConfig = formatExample(
+	"sisu",
+	[]string{"config", "path"},
+	[]string{"config", "show"},
+	[]string{"config", "edit"},
+	[]string{"config", "list"},
+	[]string{"config", "set", "target_days", "66"},
+	[]string{"config", "unset", "target_days"},
 )
 
 var exampleTask = formatExample(
 	"sisu",
 	[]string{"track"},
+	[]string{"task", "add", "--name", "Piano", "--tags", "music", "--profile", "custom"},
+	[]string{"task", "edit", "1", "--target", "2026-12-31"},
+	[]string{"task", "edit", "2", "--schedule", "mon,wed,fri"},
+	[]string{"task", "edit", "3", "--schedule", "3/week", "--goal", "1200"},
+)
+
+var exampleSession = formatExample(
+	"sisu",
+	[]string{"session", "add"},
+	[]string{"session", "add", "--task", "3", "--mins", "45", "--feedback", "4", "--notes", `"scales"`},
+	[]string{"session", "edit", "7", "--mins", "50"},
+)
+
+var exampleTag = formatExample(
+	"sisu",
+	[]string{"tag", "list"},
+	[]string{"tag", "add", "music"},
+	[]string{"tag", "rename", "music", "practice"},
+	[]string{"tag", "merge", "guitar", "piano", "music"},
+	[]string{"task", "list", "--tag", "music"},
+)
+
+var exampleProfile = formatExample(
+	"sisu",
+	[]string{"profile", "list"},
+	[]string{"profile", "show", "default"},
+	[]string{"profile", "validate", "~/.config/sisu/profiles/guitar.toml"},
+)
+
+var exampleSessionTimer = formatExample(
+	"sisu",
+	[]string{"session", "start", "3", "--class", "practice"},
+	[]string{"session", "pause"},
+	[]string{"session", "resume"},
+	[]string{"session", "status"},
+	[]string{"session", "stop"},
+)
+
+var exampleLog = formatExample(
+	"sisu",
+	[]string{"log", "piano", "1h15m", `"hanon and scales"`, "--on", "yesterday", "--score", "4"},
+	[]string{"log", "3", "45m"},
+	[]string{"log", "run", "1.5h", "--on", "last mon"},
+)
+
+var exampleStats = formatExample(
+	"sisu",
+	[]string{"stats"},
+	[]string{"stats", "piano", "--by", "week"},
+	[]string{"stats", "--from", "-30d", "--by", "class"},
+	[]string{"stats", "--tag", "music", "--from", "2025-01-01", "--to", "2025-03-31"},
+)
+
+var exampleStreak = formatExample(
+	"sisu",
+	[]string{"streak"},
+	[]string{"streak", "piano", "--grace", "1"},
+	[]string{"calendar", "add", "--date", "2025-12-25", "--kind", "holiday", "--note", "christmas"},
+)
+
+var exampleCal = formatExample(
+	"sisu",
+	[]string{"cal"},
+	[]string{"cal", "2025-03", "--task", "piano"},
+	[]string{"cal", "-i"},
+)
+
+var exampleGraph = formatExample(
+	"sisu",
+	[]string{"graph", "minutes"},
+	[]string{"graph", "feedback", "--task", "piano", "--style", "line"},
+	[]string{"graph", "sessions", "--by", "day", "--last", "30d", "--style", "spark"},
+)
+
+var exampleHeatmap = formatExample(
+	"sisu",
+	[]string{"heatmap"},
+	[]string{"heatmap", "--task", "piano", "--year", "2025"},
+	[]string{"heatmap", "--tag", "music", "--svg", "music.svg"},
+)
+
+var exampleCoachCheck = formatExample(
+	"sisu",
+	[]string{"coach", "check"},
+	[]string{"coach", "check", "piano", "--vars"},
+	[]string{"coach", "add", "--trigger", `"avg_feedback_7d < 2.5 and sessions_7d >= 3"`, "--content", `"Rough week, take it easy"`},
+)
+
+var exampleCoachPreview = formatExample(
+	"sisu",
+	[]string{"coach", "preview", "3", "--task", "piano"},
+	[]string{"coach", "edit", "3", "--content", `"{{.Task.Name}}: {{.Streak}} days in a row"`},
+)
+
+var exampleCoachHistory = formatExample(
+	"sisu",
+	[]string{"coach", "history"},
+	[]string{"coach", "history", "piano", "--last", "50"},
+)
+
+var exampleDayoff = formatExample(
+	"sisu",
+	[]string{"dayoff"},
+	[]string{"dayoff", "--task", "piano", "--yes"},
+	[]string{"dayoff", "--on", "sat", "--force"},
+)
+
+var exampleReviewStart = formatExample(
+	"sisu",
+	[]string{"review", "start", "piano"},
+	[]string{"review", "start", "piano", "--week", "3"},
+	[]string{"review", "start", "run", "--went-well", `"kept the pace"`, "--blockers", `"rain"`, "--commitment", `"3 runs"`},
+)
+
+var exampleToday = formatExample(
+	"sisu",
+	[]string{"today"},
+	[]string{"today", "--tag", "music"},
+	[]string{"today", "-q", "||", "echo", `"behind schedule"`},
+)
+
+var exampleForecast = formatExample(
+	"sisu",
+	[]string{"forecast"},
+	[]string{"forecast", "piano"},
+	[]string{"forecast", "--tag", "music"},
+)
+
+var exampleFocus = formatExample(
+	"sisu",
+	[]string{"focus", "3"},
+	[]string{"focus", "3", "--work", "50m", "--break", "10m", "--rounds", "2"},
 )
 
 var exampleMilestone = formatExample(
 	"sisu",
 	[]string{"milestone"},
+	[]string{"milestone", "add", "--task", "1", "--kind", "minutes", "--value", "600", "--message", `"ten hours in"`},
+)
+
+var exampleMilestoneCheck = formatExample(
+	"sisu",
+	[]string{"milestone", "check"},
+	[]string{"milestone", "check", "piano"},
+)
+
+var exampleMilestoneProgress = formatExample(
+	"sisu",
+	[]string{"milestone", "progress"},
+	[]string{"milestone", "progress", "--task", "piano"},
 )
 
 var exampleExport = formatExample(
@@ -0,0 +1,201 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// forecastHalfLife is the age in days at which a day weighs half as much in the EWMA fit.
+const forecastHalfLife = 7
+
+var errNoForecast = errors.New("not enough history to forecast")
+
+// forecast fits a task's cumulative minutes from its start through yesterday (today
+// is still open) and projects them to the target date.
+type forecast struct {
+	Task   *models.Task
+	Start  time.Time
+	Today  time.Time
+	Target time.Time
+	Daily  []float64 // minutes per day, Start through Today
+	Done   float64   // minutes logged so far, today included
+	Goal   float64   // 0 when the task has none
+
+	Linear float64 // slope of the least-squares line through the cumulative curve, min/day
+	Mean   float64 // exponentially weighted mean of daily minutes
+	SD     float64 // exponentially weighted standard deviation of daily minutes
+	Weight float64 // effective number of days behind Mean
+}
+
+// newForecast loads a task's daily minutes; tasks without a start date begin at their
+// first session.
+func newForecast(ctx context.Context, exec boil.ContextExecutor, t *models.Task, now time.Time) (*forecast, error) {
+	if !t.Target.Valid {
+		return nil, errors.New("task has no target date")
+	}
+	days, err := aggregateSessions(ctx, exec, bucketDay, sessionFilter{Task: t.ID.Int64, To: dateOnly(now)})
+	if err != nil {
+		return nil, err
+	}
+	mins := make(map[time.Time]float64, len(days))
+	var first time.Time
+	for _, d := range days {
+		day, err := time.Parse(DateYMD, d.Bucket)
+		if err != nil {
+			continue
+		}
+		mins[day] = float64(d.Total)
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+	}
+
+	f := &forecast{
+		Task:   t,
+		Start:  first,
+		Today:  dateOnly(now),
+		Target: dateOnly(t.Target.Time),
+	}
+	if t.Start.Valid {
+		f.Start = dateOnly(t.Start.Time)
+	}
+	if t.Goal.Valid {
+		f.Goal = float64(t.Goal.Int64)
+	}
+	if f.Start.IsZero() || !f.Start.Before(f.Today) {
+		return f, errNoForecast
+	}
+	for d := f.Start; !d.After(f.Today); d = d.AddDate(0, 0, 1) {
+		f.Daily = append(f.Daily, mins[d])
+		f.Done += mins[d]
+	}
+	f.fit()
+	return f, nil
+}
+
+// fit estimates both models from the complete days, Start through yesterday.
+func (f *forecast) fit() {
+	past := f.Daily[:len(f.Daily)-1]
+	n := float64(len(past))
+
+	// least squares through (i, cumulative minutes at the end of day i)
+	var sx, sy, sxx, sxy, cum float64
+	for i, m := range past {
+		cum += m
+		x := float64(i)
+		sx, sy, sxx, sxy = sx+x, sy+cum, sxx+x*x, sxy+x*cum
+	}
+	if den := n*sxx - sx*sx; den > 0 {
+		f.Linear = math.Max(0, (n*sxy-sx*sy)/den)
+	} else {
+		f.Linear = cum / n
+	}
+
+	// exponentially weighted daily mean and variance, oldest day first
+	alpha := 1 - math.Pow(0.5, 1.0/forecastHalfLife)
+	mean, variance := past[0], 0.0
+	for _, m := range past[1:] {
+		d := m - mean
+		mean += alpha * d
+		variance = (1 - alpha) * (variance + alpha*d*d)
+	}
+	f.Mean, f.SD = mean, math.Sqrt(variance)
+	f.Weight = math.Min(n, (2-alpha)/alpha)
+}
+
+// DaysLeft counts the days after today up to and including the target.
+func (f *forecast) DaysLeft() int { return max(0, daysBetween(f.Today, f.Target)) }
+
+// Remaining is the minutes still needed for the goal.
+func (f *forecast) Remaining() float64 { return math.Max(0, f.Goal-f.Done) }
+
+// Projected is the total expected at the target for a daily rate.
+func (f *forecast) Projected(rate float64) float64 { return f.Done + rate*float64(f.DaysLeft()) }
+
+// Reach is the day a daily rate reaches the goal; zero when it never does.
+func (f *forecast) Reach(rate float64) time.Time {
+	left := f.Remaining()
+	switch {
+	case left == 0:
+		return f.Today
+	case rate <= 0:
+		return time.Time{}
+	}
+	return f.Today.AddDate(0, 0, int(math.Ceil(left/rate)))
+}
+
+// Probability that the remaining days add up to the minutes still needed, treating
+// their sum as normal: each day varies by SD, and Mean itself is only known to within
+// SD/sqrt(Weight), an error shared by every remaining day.
+func (f *forecast) Probability() float64 {
+	left, n := f.Remaining(), float64(f.DaysLeft())
+	switch {
+	case left == 0:
+		return 1
+	case n == 0:
+		return 0
+	case f.SD == 0:
+		if f.Mean*n >= left {
+			return 1
+		}
+		return 0
+	}
+	z := (left - f.Mean*n) / (f.SD * math.Sqrt(n+n*n/f.Weight))
+	return 0.5 * math.Erfc(z/math.Sqrt2)
+}
+
+// Needed is the daily minutes from tomorrow on that reach the goal by the target.
+func (f *forecast) Needed() float64 {
+	if f.DaysLeft() == 0 {
+		return f.Remaining()
+	}
+	return f.Remaining() / float64(f.DaysLeft())
+}
+
+// Curves returns the cumulative minutes through today and both projections from today
+// to the target, on one axis from Start to the target (NaN where a curve is absent).
+func (f *forecast) Curves() (actual, linear, ewma []float64) {
+	n := max(len(f.Daily), daysBetween(f.Start, f.Target)+1)
+	actual, linear, ewma = make([]float64, n), make([]float64, n), make([]float64, n)
+	cum := 0.0
+	for i := range n {
+		if i < len(f.Daily) {
+			cum += f.Daily[i]
+			actual[i], linear[i], ewma[i] = cum, math.NaN(), math.NaN()
+			continue
+		}
+		ahead := float64(i - len(f.Daily) + 1)
+		actual[i] = math.NaN()
+		linear[i] = f.Done + f.Linear*ahead
+		ewma[i] = f.Done + f.Mean*ahead
+	}
+	return actual, linear, ewma
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -5,6 +5,8 @@ package cmd
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 import (
+	"strings"
+
 	"github.com/ttacon/chalk"
 )
 
@@ -40,13 +42,33 @@ var helpMigrate = formatHelp(
 	"Daniel Rivas",
 	"<danielrivasmd@gmail.com>",
 	"Runs every pending `.up.sql` migration against SQLite database\n"+
+		"Migrations are embedded in the binary; --migrations reads them from disk instead\n"+
 		"If the database file does not exist yet, it will be created automatically",
 )
 
+var helpMigrateForce = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"Records <version> as applied and clears the dirty flag without running any SQL\n"+
+		"Use after repairing a failed migration by hand; -1 resets to no version",
+)
+
+var helpConfig = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"The database path resolves from --db, then $SISU_DB, then `db` in the config file,\n"+
+		"then $XDG_DATA_HOME/sisu/sisu.db\n"+
+		"get/set/unset/list manage settings stored in the database: week_start, target_days,\n"+
+		"session_class, feedback_scale",
+)
+
 var helpTask = formatHelp(
 	"Daniel Rivas",
 	"<danielrivasmd@gmail.com>",
-	"Track the high-level routines or goals",
+	"Track the high-level routines or goals\n"+
+		"A schedule says how often a task calls for a session: daily (the default), weekdays such as\n"+
+		"mon,wed,fri (or weekdays, weekends), N times a week such as 3/week, or every N days;\n"+
+		"stats, streaks and coach rules measure adherence against it",
 )
 
 var helpTaskArchived = formatHelp(
@@ -55,18 +77,182 @@ var helpTaskArchived = formatHelp(
 	"Mark tasks as archived or unarchived, or toggle their archived state. Use --on, --off, or --toggle",
 )
 
+var helpTag = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"Categories shared across tasks; a task may carry several tags\n"+
+		"Names are stored lowercase; assign them through `task add` / `task edit`",
+)
+
+var helpProfile = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"Profiles are TOML templates of milestones, reviews, and coach entries seeded by `task add`\n"+
+		"A built-in `default` ships with sisu; files in ~/.config/sisu/profiles/*.toml add or override profiles by name",
+)
+
+var helpSessionTimer = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"Live timers are stored in the database, so they survive closed terminals and reboots\n"+
+		"`stop` computes minutes from the wall clock (minus pauses) and asks for feedback and notes",
+)
+
+var helpLog = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"Insert a session without any prompt\n"+
+		"The task is an ID, a name or a unique name prefix; the duration takes 90m, 1h15m, 1h30 or 1.5h\n"+
+		"Notes are the remaining arguments",
+)
+
+var helpStats = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"Total and mean minutes, session count, active days and mean feedback, aggregated in SQL\n"+
+		"Per task, hit is the share of sessions its schedule called for between start and target (or today)\n"+
+		"that were logged; breaks and holidays owe nothing\n"+
+		"With --by day|week|month, hit is the share of days with a session in each bucket; undated sessions only count per task or class",
+)
+
+var helpStreak = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"A streak counts consecutive days with a session\n"+
+		"Only days the task's schedule calls for can break it: off days of mon,wed,fri, days a 3/week\n"+
+		"quota can still be met, or days within an every-N-days interval\n"+
+		"Calendar entries of kind break or holiday never break it, and the streak_grace setting\n"+
+		"(or --grace) forgives that many missed days per week; an unlogged today is still pending",
+)
+
+var helpCal = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"Month grid shaded by session minutes, with calendar notes, milestone done dates and task targets marked\n"+
+		"--interactive browses months and days and shows a day's sessions and notes; NO_COLOR disables shading",
+)
+
+var helpGraph = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"Bar, braille line or sparkline charts of minutes, mean feedback or session count per day, week or month\n"+
+		"Charts fit the terminal width ($COLUMNS overrides) and NO_COLOR disables color",
+)
+
+var helpHeatmap = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"GitHub-style grid of daily minutes, one column per week, for a calendar year or the last 53 weeks\n"+
+		"Shades split active days into quartiles; --svg writes the same heatmap as a standalone SVG",
+)
+
+var helpCoachCheck = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"Evaluate the coach triggers scoped to each active task and deliver one firing message per task,\n"+
+		"skipping messages still in their cooldown and rotating to the least recently shown (--all lists every match)\n"+
+		"Triggers compare variables and numbers with < <= > >= == != and combine them with and, or, not and parentheses\n"+
+		"Variables: "+strings.Join(ruleVarNames(), ", ")+"\n"+
+		"A variable without a value (no scored sessions, no target) never fires a trigger, not even under not",
+)
+
+var helpCoachPreview = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"Render a coach message against a task's live stats and report whether its trigger fires\n"+
+		"Content is a Go text/template with .Task.Name, .Task.Target, .Streak, .LongestStreak, .DaysToTarget,\n"+
+		".AvgFeedback (7 days), .MinsToday, .Mins7d, .LastSession.Notes / .Date / .Mins and .Vars (every rule variable)\n"+
+		"e.g. {{.Task.Name}}: {{plural .Streak \"day\"}} in a row. Last time: {{.LastSession.Notes}}",
+)
+
+var helpCoachHistory = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"List coach messages delivered by `coach check`, newest first\n"+
+		"Deliveries drive cooldowns and rotation: a message rests for its cooldown days after being shown\n"+
+		"to a task, and the least recently shown of the firing rules is picked next",
+)
+
+var helpDayoff = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"Weigh the last 7 days against the 28 before: falling feedback, minutes well above the usual load,\n"+
+		"reviews of the last 14 days that mostly name blockers or make no commitment,\n"+
+		"and consecutive training days without rest; two or more points recommend a rest day\n"+
+		"Booking it writes a calendar break, so streaks treat the day as excused",
+)
+
+var helpReviewStart = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"Review the task week that is due: the earliest pending review seeded by the task profile,\n"+
+		"else last week or this week if not yet reviewed\n"+
+		"Shows a digest of the week (minutes, sessions, feedback trend, milestones) and last week's commitment,\n"+
+		"then asks what went well, what got in the way and what you commit to next week\n"+
+		"Without a terminal, pass at least one of --went-well, --blockers or --commitment;\n"+
+		"a review with no answers stays pending",
+)
+
+var helpToday = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"One view of the day: scheduled tasks logged (✓) or due and open (○), minutes logged so far,\n"+
+		"reviews up to each task's current week, milestones at 80% or past their due date,\n"+
+		"today's calendar entries and the coach message `coach check` would deliver next\n"+
+		"Exits 1 when anything is overdue (missed scheduled days not yet made up, reviews of past weeks,\n"+
+		"milestones past due), so `sisu today -q` can gate a shell prompt",
+)
+
+var helpForecast = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"Fit a task's cumulative minutes since its start with a least-squares line and an EWMA of daily minutes\n"+
+		"(half-life 7 days), then project both to the target date\n"+
+		"With a goal (`task edit --goal`) it reports the chance and expected date of reaching it\n"+
+		"and the daily minutes needed from tomorrow; without a task, one row per active task",
+)
+
+var helpFocus = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"Full-screen pomodoro countdown alternating work intervals and breaks\n"+
+		"Every completed work interval is logged as a session; skipped or aborted ones are not\n"+
+		"The run ends with a micro-review whose score and notes apply to the logged sessions",
+)
+
 var helpMilestone = formatHelp(
 	"Daniel Rivas",
 	"<danielrivasmd@gmail.com>",
-	"Used for incentives, streaks, or mastery checkpoints",
+	"Used for incentives, streaks, or mastery checkpoints\n"+
+		"A milestone's kind says what its value measures: minutes (total), sessions, streak (days),\n"+
+		"feedback (mean score over the last 7 days, at least 3 scored sessions), days (active) or manual\n"+
+		"Open milestones are checked after every logged session",
+)
+
+var helpMilestoneCheck = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"Evaluate every open milestone against the session history\n"+
+		"Reached milestones are stamped done with the day they were actually reached and print their message\n"+
+		"Manual milestones are left alone; set their done date with `milestone edit`",
+)
+
+var helpMilestoneProgress = formatHelp(
+	"Daniel Rivas",
+	"<danielrivasmd@gmail.com>",
+	"Progress bar per open milestone: current value against its target, the pace of the last 4 weeks\n"+
+		"(or since the first session, for younger tasks)\n"+
+		"and the date that pace reaches the target; streaks are projected as if every scheduled session is kept\n"+
+		"Milestones already reached are left out; `sisu milestone check` records them\n"+
+		"Warns when a projection lands after the task's target date",
 )
 
 var helpExport = formatHelp(
 	"Daniel Rivas",
 	"<danielrivasmd@gmail.com>",
 	"Export specified tables from the database into CSV files in the current working directory\n"+
-		"Supported tables: tasks, sessions, milestones, reviews, coach, calendar\n"+
-		"Use --all to export every supported table",
+		"Supported tables: tasks, tags, sessions, milestones, reviews, coach, calendar\n"+
+		"Use --all to export every supported table; --tag keeps only rows of tasks with that tag",
 )
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,362 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Milestone kinds; every kind but manual is reached when its measure hits value.
+const (
+	milestoneMinutes  = "minutes"  // total minutes logged
+	milestoneSessions = "sessions" // sessions logged
+	milestoneStreak   = "streak"   // streak length in days
+	milestoneFeedback = "feedback" // mean feedback over the trailing week
+	milestoneDays     = "days"     // days with a session
+	milestoneManual   = "manual"   // completed by hand
+)
+
+var milestoneKinds = []string{milestoneMinutes, milestoneSessions, milestoneStreak, milestoneFeedback, milestoneDays, milestoneManual}
+
+// feedback milestones need this many scored sessions in the trailing week
+const (
+	milestoneFeedbackDays = 7
+	milestoneFeedbackMin  = 3
+)
+
+// progress projections use the pace of the trailing four weeks
+const milestonePaceDays = 28
+
+func VMilestoneKind(s string) error {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, k := range milestoneKinds {
+		if s == k {
+			return nil
+		}
+	}
+	return fmt.Errorf("kind must be one of: %s", strings.Join(milestoneKinds, ", "))
+}
+
+// milestoneUnit describes a kind's value, e.g. "600 min".
+func milestoneUnit(kind string, v float64) string {
+	switch kind {
+	case milestoneMinutes:
+		return fmt.Sprintf("%.0f min", v)
+	case milestoneSessions:
+		return plural(int(v), "session")
+	case milestoneStreak:
+		return fmt.Sprintf("%d-day streak", int(v))
+	case milestoneFeedback:
+		return fmt.Sprintf("%.1f feedback", v)
+	case milestoneDays:
+		return plural(int(v), "active day")
+	}
+	return fmt.Sprintf("%.0f", v)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// taskHistory is a task's dated sessions summed per day, oldest first; Rules carry
+// the task's schedule for streaks.
+type taskHistory struct {
+	Days     []time.Time
+	Mins     map[time.Time]int64
+	Sessions map[time.Time]int64
+	Scores   map[time.Time][]int64
+	Rules    streakRules
+}
+
+func loadTaskHistory(ctx context.Context, exec boil.ContextExecutor, t *models.Task, rules streakRules) (*taskHistory, error) {
+	sessions, err := models.Sessions(
+		qm.Where("task = ? AND date IS NOT NULL", t.ID.Int64),
+		qm.OrderBy("date ASC, id ASC"),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	h := &taskHistory{
+		Mins:     map[time.Time]int64{},
+		Sessions: map[time.Time]int64{},
+		Scores:   map[time.Time][]int64{},
+		Rules:    rules.forTask(t),
+	}
+	for _, s := range sessions {
+		d := dateOnly(s.Date.Time)
+		if h.Sessions[d] == 0 {
+			h.Days = append(h.Days, d)
+		}
+		h.Sessions[d]++
+		h.Mins[d] += s.Mins.Int64
+		if s.Feedback.Valid {
+			h.Scores[d] = append(h.Scores[d], s.Feedback.Int64)
+		}
+	}
+	sort.Slice(h.Days, func(i, j int) bool { return h.Days[i].Before(h.Days[j]) })
+	return h, nil
+}
+
+func (h *taskHistory) active() map[time.Time]bool {
+	active := make(map[time.Time]bool, len(h.Days))
+	for _, d := range h.Days {
+		active[d] = true
+	}
+	return active
+}
+
+// measure walks the history up to and including through, calling fn with each day's
+// running value of kind; fn returns false to stop.
+func (h *taskHistory) measure(kind string, through time.Time, fn func(day time.Time, v float64) bool) {
+	var total float64
+	active := map[time.Time]bool{}
+	for i, d := range h.Days {
+		if d.After(through) {
+			return
+		}
+		var v float64
+		switch kind {
+		case milestoneMinutes:
+			total += float64(h.Mins[d])
+			v = total
+		case milestoneSessions:
+			total += float64(h.Sessions[d])
+			v = total
+		case milestoneDays:
+			v = float64(i + 1)
+		case milestoneStreak:
+			active[d] = true
+			v = float64(computeStreaks(active, h.Rules, d).Current.Days)
+		case milestoneFeedback:
+			v = h.feedbackAt(d)
+		}
+		if !fn(d, v) {
+			return
+		}
+	}
+}
+
+// feedbackAt is the mean score of the week ending on day, or 0 with too few scores.
+func (h *taskHistory) feedbackAt(day time.Time) float64 {
+	var sum, n int64
+	for d := day.AddDate(0, 0, 1-milestoneFeedbackDays); !d.After(day); d = d.AddDate(0, 0, 1) {
+		for _, s := range h.Scores[d] {
+			sum += s
+			n++
+		}
+	}
+	if n < milestoneFeedbackMin {
+		return 0
+	}
+	return float64(sum) / float64(n)
+}
+
+// reachedOn returns the first day kind reached target.
+func (h *taskHistory) reachedOn(kind string, target float64, through time.Time) (time.Time, bool) {
+	var hit time.Time
+	h.measure(kind, through, func(d time.Time, v float64) bool {
+		if v >= target {
+			hit = d
+			return false
+		}
+		return true
+	})
+	return hit, !hit.IsZero()
+}
+
+// current is kind's value as of now: running totals, the current streak, or this week's feedback.
+func (h *taskHistory) current(kind string, now time.Time) float64 {
+	day := dateOnly(now)
+	switch kind {
+	case milestoneStreak:
+		return float64(computeStreaks(h.active(), h.Rules, now).Current.Days)
+	case milestoneFeedback:
+		return h.feedbackAt(day)
+	}
+	var v float64
+	h.measure(kind, day, func(_ time.Time, x float64) bool {
+		v = x
+		return true
+	})
+	return v
+}
+
+// pace is kind's mean progress per day over the trailing window ending today,
+// shortened to the days since the first session (at least a week) for young tasks;
+// for streaks it is the share of days trained. NaN for kinds without a pace.
+func (h *taskHistory) pace(kind string, now time.Time) float64 {
+	day := dateOnly(now)
+	span := milestonePaceDays
+	if len(h.Days) > 0 {
+		span = max(7, min(span, daysBetween(h.Days[0], day)+1))
+	}
+	from := day.AddDate(0, 0, 1-span)
+	var sum float64
+	for _, d := range h.Days {
+		if d.Before(from) || d.After(day) {
+			continue
+		}
+		switch kind {
+		case milestoneMinutes:
+			sum += float64(h.Mins[d])
+		case milestoneSessions:
+			sum += float64(h.Sessions[d])
+		case milestoneDays, milestoneStreak:
+			sum++
+		default:
+			return math.NaN()
+		}
+	}
+	return sum / float64(span)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// milestoneProgress is an open milestone measured against its task's history.
+type milestoneProgress struct {
+	Milestone *models.Milestone
+	Current   float64
+	Target    float64
+	Pace      float64   // per day over the trailing window; NaN without one
+	ETA       time.Time // projected completion; zero when stalled or not projectable
+}
+
+func (p milestoneProgress) Frac() float64 {
+	if p.Target <= 0 {
+		return 1
+	}
+	return math.Min(1, p.Current/p.Target)
+}
+
+// projectMilestone measures m and projects its completion at the trailing pace.
+// Streaks are projected as if every session the schedule calls for were kept from today on.
+func (h *taskHistory) projectMilestone(m *models.Milestone, now time.Time) milestoneProgress {
+	p := milestoneProgress{
+		Milestone: m,
+		Current:   h.current(m.Kind, now),
+		Target:    float64(m.Value.Int64),
+		Pace:      h.pace(m.Kind, now),
+	}
+	left := p.Target - p.Current
+	switch {
+	case m.Kind == milestoneStreak:
+		r := h.Rules
+		p.ETA = r.Schedule.project(h.active(), r.Excused, int(math.Ceil(left)), r.WeekStart, now)
+	case math.IsNaN(p.Pace) || p.Pace <= 0:
+	default:
+		p.ETA = dateOnly(now).AddDate(0, 0, int(math.Ceil(math.Max(0, left)/p.Pace)))
+	}
+	return p
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// checkMilestones stamps open, non-manual milestones that the session history has
+// reached with the day they were reached, and returns them.
+func checkMilestones(ctx context.Context, exec boil.ContextExecutor, taskID int64, now time.Time) (models.MilestoneSlice, error) {
+	mods := []qm.QueryMod{
+		qm.Where("done IS NULL AND kind != ? AND value IS NOT NULL", milestoneManual),
+		qm.OrderBy("task ASC, id ASC"),
+	}
+	if taskID != 0 {
+		mods = append(mods, qm.Where("task = ?", taskID))
+	}
+	open, err := models.Milestones(mods...).All(ctx, exec)
+	if err != nil || len(open) == 0 {
+		return nil, err
+	}
+
+	rules, err := defaultStreakRules(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	histories := map[int64]*taskHistory{}
+	var reached models.MilestoneSlice
+	for _, m := range open {
+		h, ok := histories[m.Task]
+		if !ok {
+			t, err := models.FindTask(ctx, exec, null.Int64From(m.Task))
+			if err != nil {
+				return nil, fmt.Errorf("task %d: %w", m.Task, err)
+			}
+			if h, err = loadTaskHistory(ctx, exec, t, rules); err != nil {
+				return nil, err
+			}
+			histories[m.Task] = h
+		}
+		day, ok := h.reachedOn(m.Kind, float64(m.Value.Int64), dateOnly(now))
+		if !ok {
+			continue
+		}
+		m.Done = null.TimeFrom(day)
+		if _, err := m.Update(ctx, exec, boil.Whitelist("done")); err != nil {
+			return nil, err
+		}
+		reached = append(reached, m)
+	}
+	return reached, nil
+}
+
+// announceMilestones runs the milestone check after a session insert and prints
+// what was reached; failures go to stderr without undoing the insert.
+func announceMilestones(ctx context.Context, exec boil.ContextExecutor, taskID int64) {
+	reached, err := checkMilestones(ctx, exec, taskID, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "milestone check: %v\n", err)
+		return
+	}
+	if len(reached) == 0 {
+		return
+	}
+	names, err := taskNames(ctx, exec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "milestone check: %v\n", err)
+		return
+	}
+	for _, m := range reached {
+		fmt.Println(milestoneReachedLine(m, names[m.Task]))
+	}
+}
+
+func milestoneReachedLine(m *models.Milestone, task string) string {
+	label := m.Type.String
+	if label == "" {
+		label = m.Kind
+	}
+	line := fmt.Sprintf("★ %s: %s reached on %s (%s)", task, label,
+		m.Done.Time.Format(DateYMD), milestoneUnit(m.Kind, float64(m.Value.Int64)))
+	if m.Message.String != "" {
+		line += " - " + m.Message.String
+	}
+	return line
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
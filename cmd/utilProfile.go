@@ -0,0 +1,254 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/spf13/viper"
+
+	"github.com/DanielRivasMD/Sisu/models"
+	"github.com/DanielRivasMD/Sisu/profiles"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Profile is a TOML template of rows seeded alongside a new task.
+// Built-ins are embedded; files in ~/.config/sisu/profiles override them by name.
+type Profile struct {
+	Name        string             `mapstructure:"name"`
+	Description string             `mapstructure:"description"`
+	Milestones  []ProfileMilestone `mapstructure:"milestones"`
+	Reviews     []ProfileReview    `mapstructure:"reviews"`
+	Coach       []ProfileCoach     `mapstructure:"coach"`
+
+	Source string `mapstructure:"-"` // "built-in" or file path
+}
+
+type ProfileMilestone struct {
+	Type    string `mapstructure:"type"`
+	Kind    string `mapstructure:"kind"` // empty is manual
+	Value   int64  `mapstructure:"value"`
+	Days    int    `mapstructure:"days"` // due date, offset from the task start
+	Message string `mapstructure:"message"`
+}
+
+type ProfileReview struct {
+	Week    int64  `mapstructure:"week"`
+	Summary string `mapstructure:"summary"`
+}
+
+type ProfileCoach struct {
+	Trigger  string `mapstructure:"trigger"`
+	Content  string `mapstructure:"content"`
+	Cooldown *int64 `mapstructure:"cooldown"` // days; nil keeps the column default
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// profileNone skips seeding in `task add`.
+const profileNone = "custom"
+
+func profileDir() string {
+	return filepath.Join(configDir(), "profiles")
+}
+
+// parseProfile decodes one TOML profile, rejecting unknown keys.
+func parseProfile(data []byte, source string) (*Profile, error) {
+	v := viper.New()
+	v.SetConfigType("toml")
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	p := &Profile{}
+	if err := v.UnmarshalExact(p); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	if p.Name == "" {
+		p.Name = strings.TrimSuffix(filepath.Base(source), filepath.Ext(source))
+	}
+	p.Source = source
+	return p, nil
+}
+
+// Validate checks every row a profile would insert.
+func (p *Profile) Validate() error {
+	var errs []error
+	if strings.TrimSpace(p.Name) == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if p.Name == profileNone {
+		errs = append(errs, fmt.Errorf("name %q is reserved", profileNone))
+	}
+	for i, m := range p.Milestones {
+		if strings.TrimSpace(m.Type) == "" {
+			errs = append(errs, fmt.Errorf("milestones[%d]: type is required", i))
+		}
+		if m.Kind != "" {
+			if err := VMilestoneKind(m.Kind); err != nil {
+				errs = append(errs, fmt.Errorf("milestones[%d]: %w", i, err))
+			}
+		}
+		if m.Days < 0 {
+			errs = append(errs, fmt.Errorf("milestones[%d]: days must be >= 0", i))
+		}
+	}
+	for i, r := range p.Reviews {
+		if r.Week < 1 {
+			errs = append(errs, fmt.Errorf("reviews[%d]: week must be >= 1", i))
+		}
+	}
+	for i, c := range p.Coach {
+		if strings.TrimSpace(c.Trigger) == "" || strings.TrimSpace(c.Content) == "" {
+			errs = append(errs, fmt.Errorf("coach[%d]: trigger and content are required", i))
+		} else if err := VRule(c.Trigger); err != nil {
+			errs = append(errs, fmt.Errorf("coach[%d]: trigger: %w", i, err))
+		} else if err := VCoachContent(c.Content); err != nil {
+			errs = append(errs, fmt.Errorf("coach[%d]: content: %w", i, err))
+		}
+		if c.Cooldown != nil && *c.Cooldown < 0 {
+			errs = append(errs, fmt.Errorf("coach[%d]: cooldown must be >= 0", i))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// loadProfiles reads the built-ins, then the user directory, keyed by name.
+func loadProfiles() (map[string]*Profile, error) {
+	out := make(map[string]*Profile)
+
+	builtins, err := fs.Glob(profiles.FS, "*.toml")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range builtins {
+		data, err := profiles.FS.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		p, err := parseProfile(data, name)
+		if err != nil {
+			return nil, err
+		}
+		p.Source = "built-in"
+		out[p.Name] = p
+	}
+
+	files, err := filepath.Glob(filepath.Join(profileDir(), "*.toml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range files {
+		p, err := readProfileFile(path)
+		if err != nil {
+			return nil, err
+		}
+		out[p.Name] = p
+	}
+	return out, nil
+}
+
+func readProfileFile(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseProfile(data, path)
+}
+
+// profileNames lists available profiles, "default" first.
+func profileNames(ps map[string]*Profile) []string {
+	names := make([]string, 0, len(ps))
+	for n := range ps {
+		if n != "default" {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := ps["default"]; ok {
+		names = append([]string{"default"}, names...)
+	}
+	return names
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// seedTaskProfile inserts a profile's milestones, reviews, and coach rows for a task.
+// Run it inside the transaction that created the task.
+func seedTaskProfile(ctx context.Context, exec boil.ContextExecutor, p *Profile, taskID int64, base time.Time) error {
+	for _, pm := range p.Milestones {
+		m := &models.Milestone{
+			Task:    taskID,
+			Type:    null.StringFrom(pm.Type),
+			Kind:    strings.ToLower(pm.Kind),
+			Value:   null.Int64From(pm.Value),
+			Due:     null.TimeFrom(base.AddDate(0, 0, pm.Days)),
+			Message: null.StringFrom(pm.Message),
+		}
+		if m.Kind == "" {
+			m.Kind = milestoneManual
+		}
+		if err := m.Insert(ctx, exec, boil.Infer()); err != nil {
+			return fmt.Errorf("insert milestone: %w", err)
+		}
+	}
+
+	for _, pr := range p.Reviews {
+		r := &models.Review{
+			Task:    taskID,
+			Week:    null.Int64From(pr.Week),
+			Summary: null.StringFrom(pr.Summary),
+		}
+		if err := r.Insert(ctx, exec, boil.Infer()); err != nil {
+			return fmt.Errorf("insert review (week %d): %w", pr.Week, err)
+		}
+	}
+
+	for _, pc := range p.Coach {
+		c := &models.Coach{
+			Trigger:  pc.Trigger,
+			Content:  pc.Content,
+			Task:     null.Int64From(taskID),
+			Cooldown: 1,
+		}
+		if pc.Cooldown != nil {
+			c.Cooldown = *pc.Cooldown
+		}
+		if err := c.Insert(ctx, exec, boil.Greylist("cooldown")); err != nil {
+			return fmt.Errorf("insert coach: %w", err)
+		}
+	}
+
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,115 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/mattn/go-isatty"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// renderBar draws a width-cell progress bar for frac in [0, 1].
+func renderBar(frac float64, width int) string {
+	if width < 1 {
+		return ""
+	}
+	if frac < 0 {
+		frac = 0
+	}
+	if frac > 1 {
+		frac = 1
+	}
+	filled := int(frac*float64(width) + 0.5)
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// colorEnabled reports whether ANSI colors may be written to stdout:
+// never when NO_COLOR is set (https://no-color.org) or stdout is not a terminal.
+func colorEnabled() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	return isatty.IsTerminal(os.Stdout.Fd())
+}
+
+const (
+	ansiGreen  = 32
+	ansiYellow = 33
+	ansiCyan   = 36
+)
+
+// paint wraps s in an SGR color code; callers check colorEnabled first.
+func paint(code int, s string) string {
+	return fmt.Sprintf("\x1b[%dm%s\x1b[0m", code, s)
+}
+
+// terminalWidth is $COLUMNS, else the stdout terminal width, else 80.
+func terminalWidth() int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	if w, _, err := term.GetSize(os.Stdout.Fd()); err == nil && w > 0 {
+		return w
+	}
+	return 80
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Heat levels run 0 (nothing) to heatLevels-1 (most), GitHub-style greens on 256-color terminals.
+const heatLevels = 5
+
+var (
+	heatPalette = [heatLevels]int{237, 22, 28, 34, 40}
+	heatRunes   = [heatLevels]string{"·", "░", "▒", "▓", "█"}
+)
+
+// heatBg paints s on the background of a heat level.
+func heatBg(level int, s string) string {
+	return fmt.Sprintf("\x1b[48;5;%dm%s\x1b[0m", heatPalette[clampLevel(level)], s)
+}
+
+// heatLevel maps v onto 1..heatLevels-1 relative to max; zero stays 0.
+func heatLevel(v, max int64) int {
+	if v <= 0 || max <= 0 {
+		return 0
+	}
+	l := int((v*int64(heatLevels-1) + max - 1) / max)
+	return clampLevel(l)
+}
+
+func clampLevel(l int) int {
+	if l < 0 {
+		return 0
+	}
+	if l >= heatLevels {
+		return heatLevels - 1
+	}
+	return l
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
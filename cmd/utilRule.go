@@ -0,0 +1,386 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Coach triggers are rules over per-task statistics:
+//
+//	rule    := or
+//	or      := and { ("or" | "||") and }
+//	and     := not { ("and" | "&&") not }
+//	not     := ("not" | "!") not | "(" rule ")" | operand cmp operand
+//	cmp     := "<" | "<=" | ">" | ">=" | "==" | "=" | "!="
+//	operand := number | variable
+//
+// A variable without a value (no scored sessions, no target, ...) is NaN and makes
+// every comparison it appears in unknown rather than true or false. Unknown stays
+// unknown under not, loses to false in and, to true in or, and never fires a rule:
+// "not days_to_target < 7" is silent for a task without a target.
+
+// ruleVars documents the variables a rule may reference.
+var ruleVars = map[string]string{
+	"days_since_last_session": "days since the last session (since the task start if none)",
+	"sessions_7d":             "sessions in the last 7 days",
+	"sessions_30d":            "sessions in the last 30 days",
+	"mins_today":              "minutes logged today",
+	"mins_7d":                 "minutes in the last 7 days",
+	"mins_30d":                "minutes in the last 30 days",
+	"active_days_7d":          "days with a session in the last 7 days",
+	"avg_feedback_7d":         "mean feedback in the last 7 days",
+	"avg_feedback_30d":        "mean feedback in the last 30 days",
+	"streak":                  "current streak in days",
+	"longest_streak":          "longest streak in days",
+	"adherence_7d":            "share of scheduled sessions kept in the last 7 days (0 to 1)",
+	"adherence_30d":           "share of scheduled sessions kept in the last 30 days (0 to 1)",
+	"due_today":               "1 when the schedule calls for a session today and none is logged",
+	"days_overdue":            "scheduled days missed since the last session or the task start",
+	"days_to_target":          "days until the task target",
+	"task_days":               "days since the task start",
+	"task_week":               "task-relative week number",
+}
+
+func ruleVarNames() []string {
+	names := make([]string, 0, len(ruleVars))
+	for n := range ruleVars {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// rule is a parsed trigger.
+type rule interface {
+	truth(vars map[string]float64) ruleTruth
+}
+
+// ruleTruth orders false < unknown < true, so and is the lower side, or the higher.
+type ruleTruth int8
+
+const (
+	ruleFalse ruleTruth = iota
+	ruleUnknown
+	ruleTrue
+)
+
+// ruleFires reports whether r is true for vars; unknown does not fire.
+func ruleFires(r rule, vars map[string]float64) bool {
+	return r.truth(vars) == ruleTrue
+}
+
+type ruleOr struct{ l, r rule }
+type ruleAnd struct{ l, r rule }
+type ruleNot struct{ x rule }
+
+type ruleCmp struct {
+	op   string
+	l, r ruleOperand
+}
+
+type ruleOperand struct {
+	name string // empty for a literal
+	num  float64
+}
+
+func (n ruleOr) truth(v map[string]float64) ruleTruth  { return max(n.l.truth(v), n.r.truth(v)) }
+func (n ruleAnd) truth(v map[string]float64) ruleTruth { return min(n.l.truth(v), n.r.truth(v)) }
+func (n ruleNot) truth(v map[string]float64) ruleTruth { return ruleTrue - n.x.truth(v) }
+
+func (o ruleOperand) value(vars map[string]float64) float64 {
+	if o.name == "" {
+		return o.num
+	}
+	if v, ok := vars[o.name]; ok {
+		return v
+	}
+	return math.NaN()
+}
+
+func (n ruleCmp) truth(vars map[string]float64) ruleTruth {
+	l, r := n.l.value(vars), n.r.value(vars)
+	if math.IsNaN(l) || math.IsNaN(r) {
+		return ruleUnknown
+	}
+	var ok bool
+	switch n.op {
+	case "<":
+		ok = l < r
+	case "<=":
+		ok = l <= r
+	case ">":
+		ok = l > r
+	case ">=":
+		ok = l >= r
+	case "==", "=":
+		ok = l == r
+	case "!=":
+		ok = l != r
+	}
+	if ok {
+		return ruleTrue
+	}
+	return ruleFalse
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// parseRule compiles a trigger, rejecting unknown variables.
+func parseRule(src string) (rule, error) {
+	toks, err := lexRule(src)
+	if err != nil {
+		return nil, err
+	}
+	if len(toks) == 0 {
+		return nil, fmt.Errorf("empty rule")
+	}
+	p := &ruleParser{toks: toks}
+	r, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at offset %d", t.text, t.pos)
+	}
+	return r, nil
+}
+
+// VRule validates a coach trigger.
+func VRule(s string) error {
+	_, err := parseRule(s)
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type ruleTokKind int
+
+const (
+	tokEOF ruleTokKind = iota
+	tokNum
+	tokIdent
+	tokOp
+	tokLParen
+	tokRParen
+	tokAnd
+	tokOr
+	tokNot
+)
+
+type ruleTok struct {
+	kind ruleTokKind
+	text string
+	pos  int
+}
+
+func lexRule(src string) ([]ruleTok, error) {
+	var toks []ruleTok
+	rs := []rune(src)
+	for i := 0; i < len(rs); {
+		c := rs[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			toks = append(toks, ruleTok{tokLParen, "(", i})
+			i++
+		case c == ')':
+			toks = append(toks, ruleTok{tokRParen, ")", i})
+			i++
+		case strings.ContainsRune("<>=!&|", c):
+			two := ""
+			if i+1 < len(rs) {
+				two = string(rs[i : i+2])
+			}
+			switch two {
+			case "<=", ">=", "==", "!=":
+				toks = append(toks, ruleTok{tokOp, two, i})
+				i += 2
+				continue
+			case "&&":
+				toks = append(toks, ruleTok{tokAnd, two, i})
+				i += 2
+				continue
+			case "||":
+				toks = append(toks, ruleTok{tokOr, two, i})
+				i += 2
+				continue
+			}
+			switch c {
+			case '<', '>', '=':
+				toks = append(toks, ruleTok{tokOp, string(c), i})
+			case '!':
+				toks = append(toks, ruleTok{tokNot, "!", i})
+			default:
+				return nil, fmt.Errorf("unexpected %q at offset %d", string(c), i)
+			}
+			i++
+		case unicode.IsDigit(c) || c == '.' || c == '-':
+			j := i + 1
+			for j < len(rs) && (unicode.IsDigit(rs[j]) || rs[j] == '.') {
+				j++
+			}
+			text := string(rs[i:j])
+			if _, err := strconv.ParseFloat(text, 64); err != nil {
+				return nil, fmt.Errorf("invalid number %q at offset %d", text, i)
+			}
+			toks = append(toks, ruleTok{tokNum, text, i})
+			i = j
+		case unicode.IsLetter(c) || c == '_':
+			j := i + 1
+			for j < len(rs) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j]) || rs[j] == '_') {
+				j++
+			}
+			text := string(rs[i:j])
+			kind := tokIdent
+			switch strings.ToLower(text) {
+			case "and":
+				kind = tokAnd
+			case "or":
+				kind = tokOr
+			case "not":
+				kind = tokNot
+			}
+			toks = append(toks, ruleTok{kind, text, i})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected %q at offset %d", string(c), i)
+		}
+	}
+	return toks, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type ruleParser struct {
+	toks []ruleTok
+	i    int
+}
+
+func (p *ruleParser) peek() ruleTok {
+	if p.i >= len(p.toks) {
+		return ruleTok{kind: tokEOF, text: "end of rule", pos: len(p.toks)}
+	}
+	return p.toks[p.i]
+}
+
+func (p *ruleParser) next() ruleTok {
+	t := p.peek()
+	if p.i < len(p.toks) {
+		p.i++
+	}
+	return t
+}
+
+func (p *ruleParser) or() (rule, error) {
+	l, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		r, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		l = ruleOr{l, r}
+	}
+	return l, nil
+}
+
+func (p *ruleParser) and() (rule, error) {
+	l, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		p.next()
+		r, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		l = ruleAnd{l, r}
+	}
+	return l, nil
+}
+
+func (p *ruleParser) not() (rule, error) {
+	switch p.peek().kind {
+	case tokNot:
+		p.next()
+		x, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return ruleNot{x}, nil
+	case tokLParen:
+		p.next()
+		x, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokRParen {
+			return nil, fmt.Errorf("expected \")\" at offset %d, got %q", t.pos, t.text)
+		}
+		return x, nil
+	}
+
+	lt := p.peek()
+	l, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	op := p.next()
+	if op.kind != tokOp {
+		return nil, fmt.Errorf("expected a comparison (<, <=, >, >=, ==, !=) after %q, got %q", lt.text, op.text)
+	}
+	r, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	return ruleCmp{op: op.text, l: l, r: r}, nil
+}
+
+func (p *ruleParser) operand() (ruleOperand, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNum:
+		n, _ := strconv.ParseFloat(t.text, 64)
+		return ruleOperand{num: n}, nil
+	case tokIdent:
+		name := strings.ToLower(t.text)
+		if _, ok := ruleVars[name]; !ok {
+			return ruleOperand{}, fmt.Errorf("unknown variable %q (known: %s)", t.text, strings.Join(ruleVarNames(), ", "))
+		}
+		return ruleOperand{name: name}, nil
+	}
+	return ruleOperand{}, fmt.Errorf("expected a number or variable, got %q", t.text)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,124 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// days_to_target is missing, as for a task without a target; avg_feedback_7d is NaN
+var ruleTestVars = map[string]float64{
+	"streak":          5,
+	"mins_7d":         120,
+	"avg_feedback_7d": math.NaN(),
+}
+
+func TestRuleEval(t *testing.T) {
+	tests := []struct {
+		src  string
+		want ruleTruth
+	}{
+		// comparisons
+		{"streak > 3", ruleTrue},
+		{"streak >= 5", ruleTrue},
+		{"streak < 5", ruleFalse},
+		{"streak <= 5", ruleTrue},
+		{"streak == 5", ruleTrue},
+		{"streak = 5", ruleTrue},
+		{"streak != 5", ruleFalse},
+		{"5 <= streak", ruleTrue},
+		{"-1 < streak", ruleTrue},
+		{"mins_7d > 119.5", ruleTrue},
+		{"STREAK > 3 AND Mins_7d > 100", ruleTrue},
+
+		// precedence: not binds tightest, then and, then or
+		{"streak > 3 or streak > 10 and mins_7d > 500", ruleTrue},
+		{"(streak > 3 or streak > 10) and mins_7d > 500", ruleFalse},
+		{"not streak > 3 or mins_7d > 100", ruleTrue},
+		{"not (streak > 3 or mins_7d > 100)", ruleFalse},
+		{"!streak>3 && mins_7d>=120", ruleFalse},
+		{"streak > 10 || !(mins_7d < 100)", ruleTrue},
+		{"not not streak > 3", ruleTrue},
+		{"((streak > 3))", ruleTrue},
+
+		// missing and NaN variables are unknown, and unknown never fires
+		{"days_to_target < 7", ruleUnknown},
+		{"avg_feedback_7d < 3", ruleUnknown},
+		{"not days_to_target < 7", ruleUnknown},
+		{"not not days_to_target < 7", ruleUnknown},
+		{"days_to_target != days_to_target", ruleUnknown},
+		{"days_to_target < 7 or streak > 3", ruleTrue},
+		{"days_to_target < 7 or streak > 10", ruleUnknown},
+		{"days_to_target < 7 and streak > 3", ruleUnknown},
+		{"days_to_target < 7 and streak > 10", ruleFalse},
+		{"not (days_to_target < 7 and streak > 10)", ruleTrue},
+		{"not (days_to_target < 7 or streak > 10)", ruleUnknown},
+	}
+	for _, tt := range tests {
+		r, err := parseRule(tt.src)
+		if err != nil {
+			t.Errorf("parseRule(%q): %v", tt.src, err)
+			continue
+		}
+		if got := r.truth(ruleTestVars); got != tt.want {
+			t.Errorf("%q = %d, want %d", tt.src, got, tt.want)
+		}
+		if fires := ruleFires(r, ruleTestVars); fires != (tt.want == ruleTrue) {
+			t.Errorf("%q fires = %v", tt.src, fires)
+		}
+	}
+}
+
+func TestParseRuleErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string // substring of the error
+	}{
+		{"", "empty rule"},
+		{"   ", "empty rule"},
+		{"streak", `expected a comparison (<, <=, >, >=, ==, !=) after "streak", got "end of rule"`},
+		{"streak 3", `expected a comparison (<, <=, >, >=, ==, !=) after "streak", got "3"`},
+		{"streak >", `expected a number or variable, got "end of rule"`},
+		{"streak > and", `expected a number or variable, got "and"`},
+		{"bogus > 3", `unknown variable "bogus"`},
+		{"(streak > 3", `expected ")"`},
+		{"streak > 3)", `unexpected ")" at offset 10`},
+		{"streak > 3 mins_7d > 1", `unexpected "mins_7d" at offset 11`},
+		{"streak > 1.2.3", `invalid number "1.2.3" at offset 9`},
+		{"streak > 3 & mins_7d > 1", `unexpected "&" at offset 11`},
+		{"streak > 3 | mins_7d > 1", `unexpected "|" at offset 11`},
+		{"streak > $3", `unexpected "$" at offset 9`},
+	}
+	for _, tt := range tests {
+		_, err := parseRule(tt.src)
+		if err == nil {
+			t.Errorf("parseRule(%q) succeeded, want error containing %q", tt.src, tt.want)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("parseRule(%q) error = %q, want it to contain %q", tt.src, err, tt.want)
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
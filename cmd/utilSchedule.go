@@ -0,0 +1,310 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type scheduleKind int
+
+const (
+	scheduleDaily    scheduleKind = iota // every day
+	scheduleWeekdays                     // fixed days of the week
+	scheduleWeekly                       // N days per week, any days
+	scheduleInterval                     // at most N days between sessions
+)
+
+// schedule is how often a task calls for a session; the zero value is daily.
+type schedule struct {
+	Kind scheduleKind
+	Days [7]bool // scheduleWeekdays, indexed by time.Weekday
+	N    int     // days per week, or days between sessions
+}
+
+// parseSchedule accepts "daily", weekday lists ("mon,wed,fri", "weekdays", "weekends"),
+// "N/week" and "every N days" (or "every N day", "every Nd"); empty is daily.
+func parseSchedule(s string) (schedule, error) {
+	in := strings.ToLower(strings.Join(strings.Fields(s), " "))
+	in = strings.ReplaceAll(strings.ReplaceAll(in, " /", "/"), "/ ", "/")
+	switch in {
+	case "", "daily", "every day":
+		return schedule{}, nil
+	case "weekdays":
+		in = "mon,tue,wed,thu,fri"
+	case "weekends":
+		in = "sat,sun"
+	}
+
+	if n, ok := strings.CutSuffix(in, "/week"); ok {
+		times, err := strconv.Atoi(strings.TrimSpace(n))
+		if err != nil || times < 1 || times > 7 {
+			return schedule{}, fmt.Errorf("invalid schedule %q (use 1/week to 7/week)", s)
+		}
+		return schedule{Kind: scheduleWeekly, N: times}, nil
+	}
+
+	if n, ok := strings.CutPrefix(in, "every "); ok {
+		for _, unit := range []string{"days", "day", "d"} {
+			if v, ok := strings.CutSuffix(n, unit); ok {
+				n = v
+				break
+			}
+		}
+		every, err := strconv.Atoi(strings.TrimSpace(n))
+		if err != nil || every < 1 {
+			return schedule{}, fmt.Errorf("invalid schedule %q (use every N days, N >= 1)", s)
+		}
+		if every == 1 {
+			return schedule{}, nil
+		}
+		return schedule{Kind: scheduleInterval, N: every}, nil
+	}
+
+	sc := schedule{Kind: scheduleWeekdays}
+	for _, part := range strings.Split(in, ",") {
+		d, err := parseWeekday(part)
+		if err != nil {
+			return schedule{}, fmt.Errorf("invalid schedule %q (use daily, mon,wed,fri, 3/week or every 2 days)", s)
+		}
+		sc.Days[d] = true
+	}
+	if sc.Days == [7]bool{true, true, true, true, true, true, true} {
+		return schedule{}, nil
+	}
+	return sc, nil
+}
+
+// VSchedule validates an optional schedule field.
+func VSchedule(s string) error {
+	_, err := parseSchedule(s)
+	return err
+}
+
+func (s schedule) String() string {
+	switch s.Kind {
+	case scheduleWeekdays:
+		var days []string
+		for i := range 7 {
+			d := time.Weekday((i + 1) % 7) // monday first
+			if s.Days[d] {
+				days = append(days, weekdayShort(d))
+			}
+		}
+		return strings.Join(days, ",")
+	case scheduleWeekly:
+		return fmt.Sprintf("%d/week", s.N)
+	case scheduleInterval:
+		return fmt.Sprintf("every %d days", s.N)
+	}
+	return "daily"
+}
+
+// taskSchedule parses a task's schedule column; unset or unreadable means daily.
+func taskSchedule(t *models.Task) schedule {
+	s, err := parseSchedule(t.Schedule.String)
+	if err != nil {
+		return schedule{}
+	}
+	return s
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// due reports whether a session was owed on day d, given the active days around it
+// and today (days after today are still open):
+// weekly schedules owe a day only when the week can no longer reach N without it,
+// intervals when no session fell in the N-1 days before.
+func (s schedule) due(d time.Time, active map[time.Time]bool, weekStart time.Weekday, today time.Time) bool {
+	switch s.Kind {
+	case scheduleWeekdays:
+		return s.Days[d.Weekday()]
+	case scheduleWeekly:
+		have, open := 0, 0
+		week := startOfWeek(d, weekStart)
+		for i := range 7 {
+			day := week.AddDate(0, 0, i)
+			switch {
+			case day.Equal(d):
+			case active[day]:
+				have++
+			case day.After(d) && !day.Before(today):
+				open++
+			}
+		}
+		return have+open < s.N
+	case scheduleInterval:
+		for i := 1; i < s.N; i++ {
+			if active[d.AddDate(0, 0, -i)] {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
+
+// dueToday reports whether the schedule calls for a session today that is not logged yet.
+func (s schedule) dueToday(active, excused map[time.Time]bool, weekStart time.Weekday, now time.Time) bool {
+	day := dateOnly(now)
+	return !active[day] && !excused[day] && s.due(day, active, weekStart, day)
+}
+
+// adherence counts the sessions the schedule called for between from and to (the
+// slots) and how many were kept. Excused days owe nothing, and neither does today
+// until it is over; weekly quotas shrink for weeks cut by the range.
+func (s schedule) adherence(active, excused map[time.Time]bool, from, to time.Time, weekStart time.Weekday, now time.Time) (kept, slots int) {
+	day := dateOnly(now)
+	last := to
+	if to.After(day) {
+		to = day
+	}
+
+	if s.Kind == scheduleWeekly {
+		for week := startOfWeek(from, weekStart); !week.After(to); week = week.AddDate(0, 0, 7) {
+			span, have, open := 0, 0, 0
+			for i := range 7 {
+				d := week.AddDate(0, 0, i)
+				if d.Before(from) || d.After(last) || excused[d] {
+					continue
+				}
+				span++
+				switch {
+				case active[d] && !d.After(day):
+					have++
+				case !d.Before(day):
+					open++
+				}
+			}
+			// a week still running only owes what can no longer be made up
+			quota := int(math.Ceil(float64(s.N*span) / 7))
+			owed := max(min(have, quota), quota-open)
+			kept += min(have, owed)
+			slots += owed
+		}
+		return kept, slots
+	}
+
+	if s.Kind == scheduleInterval {
+		// each window of N days owes one session; a kept one starts the next window
+		// the day after it, a missed one right after the window, and excused days
+		// stretch the window they fall in
+		deadline := from.AddDate(0, 0, s.N-1)
+		for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+			switch {
+			case active[d]:
+				kept++
+				slots++
+				deadline = d.AddDate(0, 0, s.N)
+			case excused[d]:
+				deadline = deadline.AddDate(0, 0, 1)
+			case !d.Before(deadline) && !d.Equal(day):
+				slots++
+				deadline = d.AddDate(0, 0, s.N)
+			}
+		}
+		return kept, slots
+	}
+
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if excused[d] || !s.due(d, active, weekStart, day) {
+			continue
+		}
+		switch {
+		case active[d]:
+			kept++
+			slots++
+		case !d.Equal(day):
+			slots++
+		}
+	}
+	return kept, slots
+}
+
+// overdue counts the owed sessions missed since the last one, today excluded; with
+// nothing logged it counts from start, or from the first session when start is zero.
+// Weekly quotas count the whole week of the last session.
+func (s schedule) overdue(active, excused map[time.Time]bool, start time.Time, weekStart time.Weekday, now time.Time) int {
+	day := dateOnly(now)
+	var first, last time.Time
+	for d := range active {
+		if d.After(day) {
+			continue
+		}
+		if first.IsZero() || d.Before(first) {
+			first = d
+		}
+		if last.IsZero() || d.After(last) {
+			last = d
+		}
+	}
+
+	from := first
+	if !start.IsZero() {
+		from = dateOnly(start)
+	}
+	if from.IsZero() {
+		return 0
+	}
+	if !last.IsZero() {
+		next := last.AddDate(0, 0, 1)
+		if s.Kind == scheduleWeekly {
+			next = startOfWeek(last, weekStart)
+		}
+		if next.After(from) {
+			from = next
+		}
+	}
+
+	// running to the end of this week leaves its open days to make up the quota
+	kept, slots := s.adherence(active, excused, from, day.AddDate(0, 0, 6), weekStart, now)
+	return slots - kept
+}
+
+// project returns the day the nth session from today on falls, if every session the
+// schedule calls for is kept and none more; today counts while it is not logged.
+func (s schedule) project(active, excused map[time.Time]bool, n int, weekStart time.Weekday, now time.Time) time.Time {
+	day := dateOnly(now)
+	if n <= 0 {
+		return day
+	}
+	kept := make(map[time.Time]bool, len(active)+n)
+	for d := range active {
+		kept[d] = true
+	}
+	for d := day; ; d = d.AddDate(0, 0, 1) {
+		if kept[d] || excused[d] || !s.due(d, kept, weekStart, d) {
+			continue
+		}
+		kept[d] = true
+		if n--; n == 0 {
+			return d
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
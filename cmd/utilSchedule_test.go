@@ -0,0 +1,226 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"testing"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// the tests run on saturday 2026-10-17; weeks start on monday (10-05, 10-12)
+var schedNow = ymd("2026-10-17")
+
+func ymd(s string) time.Time {
+	d, err := time.Parse(DateYMD, s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func days(ss ...string) map[time.Time]bool {
+	m := make(map[time.Time]bool, len(ss))
+	for _, s := range ss {
+		m[ymd(s)] = true
+	}
+	return m
+}
+
+func mustSchedule(t *testing.T, s string) schedule {
+	t.Helper()
+	sc, err := parseSchedule(s)
+	if err != nil {
+		t.Fatalf("parseSchedule(%q): %v", s, err)
+	}
+	return sc
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestParseSchedule(t *testing.T) {
+	monWedFri := schedule{Kind: scheduleWeekdays}
+	monWedFri.Days[time.Monday] = true
+	monWedFri.Days[time.Wednesday] = true
+	monWedFri.Days[time.Friday] = true
+
+	tests := []struct {
+		in   string
+		want schedule
+	}{
+		{"", schedule{}},
+		{"daily", schedule{}},
+		{"Every Day", schedule{}},
+		{"every 1 day", schedule{}},
+		{"every 1 days", schedule{}},
+		{"every 2 day", schedule{Kind: scheduleInterval, N: 2}},
+		{"every 2 days", schedule{Kind: scheduleInterval, N: 2}},
+		{"every  3  days", schedule{Kind: scheduleInterval, N: 3}},
+		{"every 3d", schedule{Kind: scheduleInterval, N: 3}},
+		{"3/week", schedule{Kind: scheduleWeekly, N: 3}},
+		{"3 / week", schedule{Kind: scheduleWeekly, N: 3}},
+		{" 3/ week ", schedule{Kind: scheduleWeekly, N: 3}},
+		{"mon,wed,fri", monWedFri},
+		{"Fri, Mon, Wed", monWedFri},
+		{"weekdays", mustSchedule(t, "mon,tue,wed,thu,fri")},
+		{"mon,tue,wed,thu,fri,sat,sun", schedule{}},
+	}
+	for _, tt := range tests {
+		got, err := parseSchedule(tt.in)
+		if err != nil {
+			t.Errorf("parseSchedule(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseSchedule(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"0/week", "8/week", "x/week", "every 0 days", "every -2 days", "every days", "funday", "mon,,fri"} {
+		if got, err := parseSchedule(in); err == nil {
+			t.Errorf("parseSchedule(%q) = %v, want error", in, got)
+		}
+	}
+}
+
+func TestScheduleDue(t *testing.T) {
+	tests := []struct {
+		name   string
+		sched  string
+		active map[time.Time]bool
+		day    string
+		want   bool
+	}{
+		{"weekday off", "mon,wed,fri", nil, "2026-10-13", false},
+		{"weekday on", "mon,wed,fri", nil, "2026-10-14", true},
+		{"past week short of quota", "3/week", days("2026-10-05", "2026-10-06"), "2026-10-07", true},
+		{"past week quota met", "3/week", days("2026-10-05", "2026-10-06", "2026-10-07"), "2026-10-08", false},
+		{"running week can still make it", "3/week", days("2026-10-12"), "2026-10-13", false},
+		{"running week cannot", "3/week", nil, "2026-10-16", true},
+		{"quota of the week before", "2/week", days("2026-10-10", "2026-10-11"), "2026-10-12", false},
+		{"interval inside window", "every 3 days", days("2026-10-10"), "2026-10-12", false},
+		{"interval window over", "every 3 days", days("2026-10-10"), "2026-10-13", true},
+	}
+	for _, tt := range tests {
+		sc := mustSchedule(t, tt.sched)
+		if got := sc.due(ymd(tt.day), tt.active, time.Monday, schedNow); got != tt.want {
+			t.Errorf("%s: %s due on %s = %v, want %v", tt.name, tt.sched, tt.day, got, tt.want)
+		}
+	}
+}
+
+func TestScheduleAdherence(t *testing.T) {
+	tests := []struct {
+		name        string
+		sched       string
+		active      map[time.Time]bool
+		excused     map[time.Time]bool
+		from, to    string
+		kept, slots int
+	}{
+		// thursday start: the first week owes ceil(3*4/7) = 2, the next one 3
+		{"weekly across weeks", "3/week", days("2026-10-03", "2026-10-04", "2026-10-05", "2026-10-09"), nil,
+			"2026-10-01", "2026-10-11", 4, 5},
+		{"weekly extra sessions", "2/week", days("2026-10-05", "2026-10-06", "2026-10-07"), nil,
+			"2026-10-05", "2026-10-11", 2, 2},
+		{"weekly excused shrink quota", "3/week", days("2026-10-10"), days("2026-10-05", "2026-10-06", "2026-10-07", "2026-10-08"),
+			"2026-10-05", "2026-10-11", 1, 2},
+		{"weekly running, on track", "3/week", days("2026-10-12", "2026-10-13"), nil,
+			"2026-10-12", "2026-10-18", 2, 2},
+		{"weekly running, behind", "3/week", nil, nil,
+			"2026-10-12", "2026-10-18", 0, 1},
+		{"interval", "every 3 days", days("2026-10-02", "2026-10-09"), nil,
+			"2026-10-01", "2026-10-12", 2, 5},
+		{"interval excused stretch window", "every 3 days", days("2026-10-02", "2026-10-09"), days("2026-10-04", "2026-10-05"),
+			"2026-10-01", "2026-10-12", 2, 4},
+		{"interval today still open", "every 2 days", days("2026-10-15"), nil,
+			"2026-10-15", "2026-10-17", 1, 1},
+		{"weekdays", "mon,wed,fri", days("2026-10-05", "2026-10-06", "2026-10-09"), days("2026-10-07"),
+			"2026-10-05", "2026-10-11", 2, 2},
+		{"daily today not owed", "daily", days("2026-10-15"), nil,
+			"2026-10-14", "2026-10-17", 1, 3},
+	}
+	for _, tt := range tests {
+		sc := mustSchedule(t, tt.sched)
+		kept, slots := sc.adherence(tt.active, tt.excused, ymd(tt.from), ymd(tt.to), time.Monday, schedNow)
+		if kept != tt.kept || slots != tt.slots {
+			t.Errorf("%s: adherence = %d/%d, want %d/%d", tt.name, kept, slots, tt.kept, tt.slots)
+		}
+	}
+}
+
+func TestScheduleOverdue(t *testing.T) {
+	tests := []struct {
+		name    string
+		sched   string
+		active  map[time.Time]bool
+		excused map[time.Time]bool
+		start   string
+		want    int
+	}{
+		{"no sessions", "daily", nil, nil, "2026-10-01", 16},
+		{"no sessions, excused", "daily", nil, days("2026-10-03", "2026-10-04"), "2026-10-01", 14},
+		{"no sessions, no start", "daily", nil, nil, "", 0},
+		{"no sessions, starts later", "daily", nil, nil, "2026-10-20", 0},
+		{"no start, first session", "daily", days("2026-10-10"), nil, "", 6},
+		{"since last session", "daily", days("2026-10-01", "2026-10-14"), nil, "2026-10-01", 2},
+		{"logged today", "daily", days("2026-10-17"), nil, "2026-10-01", 0},
+		{"weekdays", "mon,wed,fri", days("2026-10-09"), nil, "2026-10-01", 3},
+		{"weekly no sessions", "3/week", nil, nil, "2026-10-01", 6},
+		{"weekly on track", "3/week", days("2026-10-12", "2026-10-13"), nil, "2026-10-01", 0},
+		{"weekly short last week", "3/week", days("2026-10-09"), nil, "2026-10-01", 3},
+		{"interval no sessions", "every 3 days", nil, nil, "2026-10-01", 5},
+		{"interval since last", "every 3 days", days("2026-10-12"), nil, "2026-10-01", 1},
+	}
+	for _, tt := range tests {
+		sc := mustSchedule(t, tt.sched)
+		var start time.Time
+		if tt.start != "" {
+			start = ymd(tt.start)
+		}
+		if got := sc.overdue(tt.active, tt.excused, start, time.Monday, schedNow); got != tt.want {
+			t.Errorf("%s: overdue = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestScheduleProject(t *testing.T) {
+	tests := []struct {
+		sched  string
+		active map[time.Time]bool
+		n      int
+		want   string
+	}{
+		{"daily", nil, 0, "2026-10-17"},
+		{"daily", nil, 3, "2026-10-19"},
+		{"daily", days("2026-10-17"), 1, "2026-10-18"},
+		{"mon,wed,fri", nil, 3, "2026-10-23"},
+		{"3/week", days("2026-10-12", "2026-10-13"), 1, "2026-10-18"},
+		{"every 3 days", days("2026-10-16"), 2, "2026-10-22"},
+	}
+	for _, tt := range tests {
+		sc := mustSchedule(t, tt.sched)
+		if got := sc.project(tt.active, nil, tt.n, time.Monday, schedNow); !got.Equal(ymd(tt.want)) {
+			t.Errorf("%s: session %d projected on %s, want %s", tt.sched, tt.n, got.Format(DateYMD), tt.want)
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
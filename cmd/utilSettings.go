@@ -0,0 +1,211 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+
+	"github.com/DanielRivasMD/Sisu/db"
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Schema (config):
+//   key TEXT PK        → string
+//   value TEXT NOT NULL → string, validated and normalized per Setting
+
+const (
+	settingWeekStart     = "week_start"
+	settingTargetDays    = "target_days"
+	settingSessionClass  = "session_class"
+	settingFeedbackScale = "feedback_scale"
+	settingStreakGrace   = "streak_grace"
+)
+
+// Setting describes one typed key of the config table.
+type Setting struct {
+	Key       string
+	Default   string
+	Help      string
+	Validate  func(string) error
+	Normalize func(string) string // optional; applied before storing
+}
+
+var settings = map[string]Setting{
+	settingWeekStart: {
+		Key:     settingWeekStart,
+		Default: "mon",
+		Help:    "first day of the week (mon ... sun)",
+		Validate: func(s string) error {
+			_, err := parseWeekday(s)
+			return err
+		},
+		Normalize: func(s string) string {
+			d, _ := parseWeekday(s)
+			return weekdayShort(d)
+		},
+	},
+	settingTargetDays: {
+		Key:      settingTargetDays,
+		Default:  "100",
+		Help:     "days from start used as the default task target",
+		Validate: VIntRange(1, 3650),
+	},
+	settingSessionClass: {
+		Key:       settingSessionClass,
+		Default:   "",
+		Help:      "class pre-filled for new sessions",
+		Validate:  func(string) error { return nil },
+		Normalize: strings.TrimSpace,
+	},
+	settingFeedbackScale: {
+		Key:      settingFeedbackScale,
+		Default:  "5",
+		Help:     "highest feedback score (scores run 1..N)",
+		Validate: VIntRange(2, 10),
+	},
+	settingStreakGrace: {
+		Key:      settingStreakGrace,
+		Default:  "0",
+		Help:     "missed days per week that do not break a streak",
+		Validate: VIntRange(0, 6),
+	},
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// lookupSetting resolves a known key or explains which keys exist.
+func lookupSetting(key string) (Setting, error) {
+	s, ok := settings[key]
+	if !ok {
+		return Setting{}, fmt.Errorf("unknown setting %q (known: %s)", key, strings.Join(settingKeys(), ", "))
+	}
+	return s, nil
+}
+
+func settingKeys() []string {
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getSetting returns the stored value, or the default when unset.
+// stored reports whether the value came from the table.
+func getSetting(ctx context.Context, exec boil.ContextExecutor, key string) (value string, stored bool, err error) {
+	s, err := lookupSetting(key)
+	if err != nil {
+		return "", false, err
+	}
+	row, err := models.FindConfig(ctx, exec, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return s.Default, false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("read setting %q: %w", key, err)
+	}
+	return row.Value, true, nil
+}
+
+// setSetting validates, normalizes and stores a value.
+func setSetting(ctx context.Context, exec boil.ContextExecutor, key, value string) (string, error) {
+	s, err := lookupSetting(key)
+	if err != nil {
+		return "", err
+	}
+	if err := s.Validate(value); err != nil {
+		return "", fmt.Errorf("%s: %w", key, err)
+	}
+	if s.Normalize != nil {
+		value = s.Normalize(value)
+	} else {
+		value = strings.TrimSpace(value)
+	}
+	row := &models.Config{Key: key, Value: value}
+	if err := row.Upsert(ctx, exec, true, []string{"key"}, boil.Whitelist("value"), boil.Infer()); err != nil {
+		return "", fmt.Errorf("store setting %q: %w", key, err)
+	}
+	return value, nil
+}
+
+// unsetSetting removes a stored value so the default applies again.
+func unsetSetting(ctx context.Context, exec boil.ContextExecutor, key string) error {
+	if _, err := lookupSetting(key); err != nil {
+		return err
+	}
+	row, err := models.FindConfig(ctx, exec, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read setting %q: %w", key, err)
+	}
+	_, err = row.Delete(ctx, exec)
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Typed accessors for commands; they read through db.Conn and exit on failure
+// like the rest of the command layer.
+
+func settingString(key string) string {
+	v, _, err := getSetting(db.Ctx(), db.Conn, key)
+	if err != nil {
+		log.Fatalf("setting: %v", err)
+	}
+	return v
+}
+
+func settingInt(key string) int64 {
+	n, err := strconv.ParseInt(settingString(key), 10, 64)
+	if err != nil {
+		log.Fatalf("setting %s: %v", key, err)
+	}
+	return n
+}
+
+// settingDefaultInt is the built-in default, for use before the database is open.
+func settingDefaultInt(key string) int64 {
+	n, _ := strconv.ParseInt(settings[key].Default, 10, 64)
+	return n
+}
+
+func settingWeekday(key string) time.Weekday {
+	d, err := parseWeekday(settingString(key))
+	if err != nil {
+		log.Fatalf("setting %s: %v", key, err)
+	}
+	return d
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,164 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// sessionFilter narrows aggregate queries over sessions; zero values mean "no limit".
+type sessionFilter struct {
+	Task int64
+	Tag  string
+	From time.Time
+	To   time.Time
+}
+
+// mods turns the filter into where clauses on the sessions table aliased as s.
+func (f sessionFilter) mods() []qm.QueryMod {
+	var mods []qm.QueryMod
+	if f.Task != 0 {
+		mods = append(mods, qm.Where("s.task = ?", f.Task))
+	}
+	if !f.From.IsZero() {
+		mods = append(mods, qm.Where("date(s.date) >= ?", f.From.Format(DateYMD)))
+	}
+	if !f.To.IsZero() {
+		mods = append(mods, qm.Where("date(s.date) <= ?", f.To.Format(DateYMD)))
+	}
+	return withTaskTag("s.task", f.Tag, mods...)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// sessionAgg is one group of sessions summarized in SQL.
+type sessionAgg struct {
+	Bucket   string       `boil:"bucket"`
+	Sessions int64        `boil:"sessions"`
+	Total    int64        `boil:"total"`
+	Mean     null.Float64 `boil:"mean"`
+	Days     int64        `boil:"days"`
+	Feedback null.Float64 `boil:"feedback"`
+}
+
+// Bucket expressions over sessions s; week starts are rendered as their YYYY-MM-DD.
+const (
+	bucketDay   = "date(s.date)"
+	bucketMonth = "strftime('%Y-%m', s.date)"
+	bucketClass = "coalesce(nullif(s.class, ''), '-')"
+	bucketTask  = "s.task"
+	bucketAll   = "'all'"
+)
+
+func bucketWeek(weekStart time.Weekday) string {
+	return fmt.Sprintf("date(s.date, '-' || ((cast(strftime('%%w', s.date) AS integer) - %d + 7) %% 7) || ' days')", int(weekStart))
+}
+
+// aggregateSessions groups the filtered sessions by the bucket expression.
+// Time buckets skip undated sessions; active days only ever count dated ones.
+func aggregateSessions(ctx context.Context, exec boil.ContextExecutor, bucket string, f sessionFilter) ([]sessionAgg, error) {
+	mods := append([]qm.QueryMod{
+		qm.Select(
+			bucket+" AS bucket",
+			"count(*) AS sessions",
+			"coalesce(sum(s.mins), 0) AS total",
+			"avg(s.mins) AS mean",
+			"count(DISTINCT date(s.date)) AS days",
+			"avg(s.feedback) AS feedback",
+		),
+		qm.From("sessions s"),
+		qm.Where(bucket + " IS NOT NULL"),
+		qm.GroupBy("bucket"),
+		qm.OrderBy("bucket ASC"),
+	}, f.mods()...)
+
+	var rows []sessionAgg
+	if err := models.NewQuery(mods...).Bind(ctx, exec, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// hitRate is the share of days in [from, to] with a session; ok is false for an empty span.
+func hitRate(days int64, from, to time.Time) (float64, bool) {
+	span := daysBetween(from, to) + 1
+	if span <= 0 {
+		return 0, false
+	}
+	return float64(days) / float64(span), true
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// bucketStarts returns the last n day, week or month bucket starts ending at now, oldest first.
+func bucketStarts(by string, n int, weekStart time.Weekday, now time.Time) []time.Time {
+	cur := dateOnly(now)
+	switch by {
+	case "week":
+		cur = startOfWeek(cur, weekStart)
+	case "month":
+		cur = time.Date(cur.Year(), cur.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	out := make([]time.Time, n)
+	for i := range out {
+		back := n - 1 - i
+		switch by {
+		case "week":
+			out[i] = cur.AddDate(0, 0, -7*back)
+		case "month":
+			out[i] = cur.AddDate(0, -back, 0)
+		default:
+			out[i] = cur.AddDate(0, 0, -back)
+		}
+	}
+	return out
+}
+
+// bucketKey renders a bucket start the way the matching SQL bucket expression does.
+func bucketKey(by string, t time.Time) string {
+	if by == "month" {
+		return t.Format("2006-01")
+	}
+	return t.Format(DateYMD)
+}
+
+// timeBucket is the SQL expression for a day, week or month bucket.
+func timeBucket(by string, weekStart time.Weekday) string {
+	switch by {
+	case "week":
+		return bucketWeek(weekStart)
+	case "month":
+		return bucketMonth
+	}
+	return bucketDay
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
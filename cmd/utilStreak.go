@@ -0,0 +1,168 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// streak is a run of active days; excused and grace days extend it without counting.
+type streak struct {
+	Start time.Time
+	End   time.Time // last active day
+	Days  int       // active days in the run
+}
+
+// streakRules decide which missed days are forgiven.
+type streakRules struct {
+	Excused   map[time.Time]bool // calendar breaks and holidays
+	Grace     int                // missed days forgiven per week
+	WeekStart time.Weekday
+	Schedule  schedule // days the schedule does not call for never break a run
+}
+
+// forTask applies a task's schedule to the shared rules.
+func (r streakRules) forTask(t *models.Task) streakRules {
+	r.Schedule = taskSchedule(t)
+	return r
+}
+
+// streakResult holds the run still alive today (if any) and the longest one.
+type streakResult struct {
+	Current streak
+	Longest streak
+}
+
+// computeStreaks walks from the first active day up to today. Today only extends
+// a streak; an unlogged today never breaks one, since the day is not over.
+func computeStreaks(active map[time.Time]bool, rules streakRules, now time.Time) streakResult {
+	var res streakResult
+	var first time.Time
+	for d := range active {
+		if first.IsZero() || d.Before(first) {
+			first = d
+		}
+	}
+	if first.IsZero() {
+		return res
+	}
+
+	end := dateOnly(now)
+	var run streak
+	inRun := false
+	missed := map[time.Time]int{} // grace used per week start
+
+	closeRun := func() {
+		if inRun && run.Days > res.Longest.Days {
+			res.Longest = run
+		}
+		inRun = false
+	}
+
+	for d := first; !d.After(end); d = d.AddDate(0, 0, 1) {
+		switch {
+		case active[d]:
+			if !inRun {
+				run, inRun = streak{Start: d}, true
+			}
+			run.End = d
+			run.Days++
+		case !inRun, rules.Excused[d], d.Equal(end), !rules.Schedule.due(d, active, rules.WeekStart, end):
+			// nothing to break, or forgiven
+		default:
+			week := startOfWeek(d, rules.WeekStart)
+			if missed[week] < rules.Grace {
+				missed[week]++
+				continue
+			}
+			closeRun()
+		}
+	}
+
+	if inRun {
+		res.Current = run
+	}
+	closeRun()
+	return res
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// activeDays returns the calendar days with at least one dated session for the task.
+func activeDays(ctx context.Context, exec boil.ContextExecutor, taskID int64) (map[time.Time]bool, error) {
+	aggs, err := aggregateSessions(ctx, exec, bucketDay, sessionFilter{Task: taskID})
+	if err != nil {
+		return nil, err
+	}
+	days := make(map[time.Time]bool, len(aggs))
+	for _, a := range aggs {
+		if d, err := time.Parse(DateYMD, a.Bucket); err == nil {
+			days[d] = true
+		}
+	}
+	return days, nil
+}
+
+// excusedDays returns the days covered by calendar breaks and holidays.
+func excusedDays(ctx context.Context, exec boil.ContextExecutor) (map[time.Time]bool, error) {
+	rows, err := models.Calendars(
+		qm.Where("date IS NOT NULL"),
+		qm.WhereIn("kind IN ?", calendarBreak, calendarHoliday),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	days := make(map[time.Time]bool, len(rows))
+	for _, c := range rows {
+		days[dateOnly(c.Date.Time)] = true
+	}
+	return days, nil
+}
+
+// defaultStreakRules reads the excused calendar days and grace settings.
+func defaultStreakRules(ctx context.Context, exec boil.ContextExecutor) (streakRules, error) {
+	excused, err := excusedDays(ctx, exec)
+	if err != nil {
+		return streakRules{}, err
+	}
+	return streakRules{
+		Excused:   excused,
+		Grace:     int(settingInt(settingStreakGrace)),
+		WeekStart: settingWeekday(settingWeekStart),
+	}, nil
+}
+
+// taskStreaks computes a task's current and longest streak under rules and its schedule.
+func taskStreaks(ctx context.Context, exec boil.ContextExecutor, t *models.Task, rules streakRules) (streakResult, error) {
+	active, err := activeDays(ctx, exec, t.ID.Int64)
+	if err != nil {
+		return streakResult{}, err
+	}
+	return computeStreaks(active, rules.forTask(t), time.Now()), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,157 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Sisu/db"
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// normalizeTag lowercases and trims a tag name; commas are reserved as separators.
+func normalizeTag(s string) (string, error) {
+	n := strings.ToLower(strings.TrimSpace(s))
+	if n == "" {
+		return "", errors.New("tag name cannot be blank")
+	}
+	if strings.Contains(n, ",") {
+		return "", fmt.Errorf("tag %q cannot contain commas", s)
+	}
+	return n, nil
+}
+
+// parseTags splits comma-separated input into normalized, de-duplicated names.
+func parseTags(s string) []string {
+	seen := make(map[string]struct{})
+	var out []string
+	for _, raw := range strings.Split(s, ",") {
+		n, err := normalizeTag(raw)
+		if err != nil {
+			continue
+		}
+		if _, dup := seen[n]; dup {
+			continue
+		}
+		seen[n] = struct{}{}
+		out = append(out, n)
+	}
+	return out
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// findTag looks up a tag by its normalized name.
+func findTag(ctx context.Context, exec boil.ContextExecutor, name string) (*models.Tag, error) {
+	n, err := normalizeTag(name)
+	if err != nil {
+		return nil, err
+	}
+	t, err := models.Tags(qm.Where("name = ?", n)).One(ctx, exec)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("tag %q not found", n)
+	}
+	return t, err
+}
+
+// ensureTags returns the rows for names, creating any that do not exist yet.
+func ensureTags(ctx context.Context, exec boil.ContextExecutor, names []string) (models.TagSlice, error) {
+	tags := make(models.TagSlice, 0, len(names))
+	for _, n := range names {
+		t, err := models.Tags(qm.Where("name = ?", n)).One(ctx, exec)
+		if errors.Is(err, sql.ErrNoRows) {
+			t = &models.Tag{Name: n}
+			if err := t.Insert(ctx, exec, boil.Infer()); err != nil {
+				return nil, fmt.Errorf("insert tag %q: %w", n, err)
+			}
+		} else if err != nil {
+			return nil, fmt.Errorf("find tag %q: %w", n, err)
+		}
+		tags = append(tags, t)
+	}
+	return tags, nil
+}
+
+// setTaskTags replaces every tag on a task with names.
+func setTaskTags(ctx context.Context, exec boil.ContextExecutor, task *models.Task, names []string) error {
+	tags, err := ensureTags(ctx, exec, names)
+	if err != nil {
+		return err
+	}
+	return task.SetTags(ctx, exec, false, tags...)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// taskTagsString renders a task's tags as "a, b"; callers load them with
+// qm.Load(models.TaskRels.Tags), and a task without them renders "".
+func taskTagsString(t *models.Task) string {
+	tags := t.GetTags()
+	names := make([]string, 0, len(tags))
+	for _, g := range tags {
+		names = append(names, g.Name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// withTaskTag appends a filter keeping rows whose task column carries tag.
+// col is "id" for tasks and "task" for tables referencing tasks.
+func withTaskTag(col, tag string, mods ...qm.QueryMod) []qm.QueryMod {
+	if strings.TrimSpace(tag) == "" {
+		return mods
+	}
+	return append(mods, qm.Where(
+		col+" IN (SELECT tt.task FROM tasks_tags tt JOIN tags g ON g.id = tt.tag WHERE g.name = ?)",
+		strings.ToLower(strings.TrimSpace(tag)),
+	))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// completeTagNames offers existing tag names for args and --tag flags.
+func completeTagNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if err := EnsureDB(); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	tags, err := models.Tags(qm.OrderBy("name ASC")).All(db.Ctx(), db.Conn)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	comps := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if strings.HasPrefix(t.Name, toComplete) {
+			comps = append(comps, t.Name)
+		}
+	}
+	return comps, cobra.ShellCompDirectiveNoFileComp
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
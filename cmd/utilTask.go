@@ -0,0 +1,119 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Sisu/db"
+	"github.com/DanielRivasMD/Sisu/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// resolveTask finds the task named by a command argument: its ID, its exact
+// name, or a name prefix matching exactly one task (all case-insensitive).
+func resolveTask(ctx context.Context, exec boil.ContextExecutor, arg string) (*models.Task, error) {
+	arg = strings.TrimSpace(arg)
+	if arg == "" {
+		return nil, errors.New("empty task")
+	}
+	if id, err := strconv.ParseInt(arg, 10, 64); err == nil {
+		t, err := models.FindTask(ctx, exec, null.Int64From(id))
+		if !errors.Is(err, sql.ErrNoRows) {
+			return t, err
+		}
+	}
+
+	tasks, err := models.Tasks(qm.OrderBy("id ASC")).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	needle := strings.ToLower(arg)
+	var matches models.TaskSlice
+	for _, t := range tasks {
+		name := strings.ToLower(t.Name)
+		if name == needle {
+			return t, nil
+		}
+		if strings.HasPrefix(name, needle) {
+			matches = append(matches, t)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("task %q not found", arg)
+	case 1:
+		return matches[0], nil
+	}
+	names := make([]string, len(matches))
+	for i, t := range matches {
+		names[i] = fmt.Sprintf("%d:%s", t.ID.Int64, t.Name)
+	}
+	return nil, fmt.Errorf("task %q is ambiguous (%s)", arg, strings.Join(names, ", "))
+}
+
+// taskNames maps every task ID to its name.
+func taskNames(ctx context.Context, exec boil.ContextExecutor) (map[int64]string, error) {
+	tasks, err := models.Tasks().All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(tasks))
+	for _, t := range tasks {
+		names[t.ID.Int64] = t.Name
+	}
+	return names, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// completeTaskArg offers unarchived task IDs for a single leading task argument.
+func completeTaskArg(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return buildIDCompletions(
+		db.Ctx(),
+		nil,
+		func(ctx context.Context, conn *sql.DB) ([]*models.Task, error) {
+			return models.Tasks(
+				qm.Where("coalesce(archived, 0) = 0"),
+				qm.OrderBy("id ASC"),
+			).All(ctx, conn)
+		},
+		func(t *models.Task) (int64, string) { return t.ID.Int64, t.Name },
+		toComplete,
+		nil,
+		nil,
+	)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -24,8 +24,6 @@ import (
 	"fmt"
 
 	"github.com/golang-migrate/migrate/v4"
-	sqlitem "github.com/golang-migrate/migrate/v4/database/sqlite"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/mattn/go-sqlite3"
 
 	"github.com/aarondl/sqlboiler/v4/boil"
@@ -37,25 +35,29 @@ var Conn *sql.DB
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// InitDB opens the file, applies migrations, and hooks up SQLBoiler.
-func InitDB(path string) (*sql.DB, error) {
+// Open opens the file without touching its schema.
+func Open(path string) (*sql.DB, error) {
 	db, err := sql.Open("sqlite3", path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
+	return db, nil
+}
 
-	driver, err := sqlitem.WithInstance(db, &sqlitem.Config{})
+// InitDB opens the file, applies migrations, and hooks up SQLBoiler.
+func InitDB(path string) (*sql.DB, error) {
+	db, err := Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("initializing migrations: %w", err)
+		return nil, err
 	}
 
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://migrations",
-		"sqlite3",
-		driver,
-	)
+	m, err := NewMigrate(db)
 	if err != nil {
-		return nil, fmt.Errorf("initializing migrations: %w", err)
+		return nil, err
+	}
+
+	if err := CheckSchema(m); err != nil {
+		return nil, err
 	}
 
 	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
@@ -18,12 +18,131 @@ package db
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// MigrationsDir is the relative path (from your binary's working directory)
-// to where your golang-migrate files live.
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+
+	"github.com/golang-migrate/migrate/v4"
+	sqlitem "github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"github.com/DanielRivasMD/Sisu/migrations"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MigrationsDir optionally points at a golang-migrate directory on disk.
+// Empty (the default) uses the migrations embedded in the binary;
+// set it during development to iterate on `.sql` files without rebuilding.
 //
-// Expected layout at project root:
+// Expected layout:
 //
 //	migrations/
-const MigrationsDir = "migrations"
+var MigrationsDir string
+
+// ErrSchemaTooNew reports a database migrated by a newer binary.
+var ErrSchemaTooNew = errors.New("database schema is newer than this binary")
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MigrationStatus describes where a database sits relative to the known migrations.
+type MigrationStatus struct {
+	Version uint     // applied version; 0 when nothing has been applied
+	Dirty   bool     // a migration failed midway and needs `force`
+	Latest  uint     // highest version this binary knows about
+	Pending []string // `.up.sql` files not yet applied, in order
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// migrationsFS returns MigrationsDir when set, the embedded files otherwise.
+func migrationsFS() fs.FS {
+	if MigrationsDir != "" {
+		return os.DirFS(MigrationsDir)
+	}
+	return migrations.FS
+}
+
+// upMigrations lists every `.up.sql` migration sorted by version.
+func upMigrations() ([]*source.Migration, error) {
+	entries, err := fs.ReadDir(migrationsFS(), ".")
+	if err != nil {
+		return nil, fmt.Errorf("reading migrations: %w", err)
+	}
+	var ups []*source.Migration
+	for _, e := range entries {
+		mig, err := source.Parse(e.Name())
+		if err != nil || mig.Direction != source.Up {
+			continue
+		}
+		ups = append(ups, mig)
+	}
+	sort.Slice(ups, func(i, j int) bool { return ups[i].Version < ups[j].Version })
+	return ups, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NewMigrate binds golang-migrate to an open database, reading migrations
+// from MigrationsDir when set, or from the embedded files otherwise.
+func NewMigrate(db *sql.DB) (*migrate.Migrate, error) {
+	driver, err := sqlitem.WithInstance(db, &sqlitem.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("initializing migrations: %w", err)
+	}
+
+	src, err := iofs.New(migrationsFS(), ".")
+	if err != nil {
+		return nil, fmt.Errorf("reading migrations: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, "sqlite3", driver)
+	if err != nil {
+		return nil, fmt.Errorf("initializing migrations: %w", err)
+	}
+	return m, nil
+}
+
+// Status reports the applied version, dirty flag and pending files.
+func Status(m *migrate.Migrate) (MigrationStatus, error) {
+	var st MigrationStatus
+
+	v, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return st, fmt.Errorf("reading schema version: %w", err)
+	}
+	st.Version, st.Dirty = v, dirty
+
+	ups, err := upMigrations()
+	if err != nil {
+		return st, err
+	}
+	for _, mig := range ups {
+		st.Latest = mig.Version
+		if mig.Version > st.Version {
+			st.Pending = append(st.Pending, mig.Raw)
+		}
+	}
+	return st, nil
+}
+
+// CheckSchema refuses databases migrated past the newest known migration,
+// so an old binary never writes to a schema it does not understand.
+func CheckSchema(m *migrate.Migrate) error {
+	st, err := Status(m)
+	if err != nil {
+		return err
+	}
+	if st.Version > st.Latest {
+		return fmt.Errorf("%w: database is at version %d, binary knows up to %d",
+			ErrSchemaTooNew, st.Version, st.Latest)
+	}
+	return nil
+}
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,15 @@
+----------------------------------------------------------------------------------------------------
+ALTER TABLE tasks ADD COLUMN tag text;
+
+UPDATE tasks SET tag = (
+	SELECT group_concat(g.name, ',')
+	FROM tasks_tags tt JOIN tags g ON g.id = tt.tag
+	WHERE tt.task = tasks.id
+);
+
+----------------------------------------------------------------------------------------------------
+DROP TABLE IF EXISTS tasks_tags;
+
+DROP TABLE IF EXISTS tags;
+
+----------------------------------------------------------------------------------------------------
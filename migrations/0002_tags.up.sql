@@ -0,0 +1,43 @@
+----------------------------------------------------------------------------------------------------
+PRAGMA foreign_keys = ON;
+
+----------------------------------------------------------------------------------------------------
+CREATE TABLE IF NOT EXISTS tags (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	name text NOT NULL UNIQUE
+);
+
+----------------------------------------------------------------------------------------------------
+CREATE TABLE IF NOT EXISTS tasks_tags (
+	task integer NOT NULL,
+	tag integer NOT NULL,
+	PRIMARY KEY (task, tag),
+	FOREIGN KEY (task) REFERENCES tasks (id) ON DELETE CASCADE,
+	FOREIGN KEY (tag) REFERENCES tags (id) ON DELETE CASCADE
+);
+
+----------------------------------------------------------------------------------------------------
+-- backfill: split comma-separated tasks.tag into one normalized tag per item
+CREATE TEMP TABLE tag_split AS
+WITH RECURSIVE split (task, item, rest) AS (
+	SELECT id, '', tag || ',' FROM tasks WHERE trim(coalesce(tag, '')) <> ''
+	UNION ALL
+	SELECT task,
+		lower(trim(substr(rest, 1, instr(rest, ',') - 1))),
+		substr(rest, instr(rest, ',') + 1)
+	FROM split WHERE rest <> ''
+)
+SELECT DISTINCT task, item FROM split WHERE item <> '';
+
+INSERT OR IGNORE INTO tags (name)
+SELECT DISTINCT item FROM tag_split ORDER BY item;
+
+INSERT OR IGNORE INTO tasks_tags (task, tag)
+SELECT s.task, g.id FROM tag_split s JOIN tags g ON g.name = s.item;
+
+DROP TABLE tag_split;
+
+----------------------------------------------------------------------------------------------------
+ALTER TABLE tasks DROP COLUMN tag;
+
+----------------------------------------------------------------------------------------------------
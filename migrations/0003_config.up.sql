@@ -0,0 +1,7 @@
+----------------------------------------------------------------------------------------------------
+CREATE TABLE IF NOT EXISTS config (
+	key text PRIMARY KEY NOT NULL,
+	value text NOT NULL
+);
+
+----------------------------------------------------------------------------------------------------
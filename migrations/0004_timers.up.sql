@@ -0,0 +1,13 @@
+----------------------------------------------------------------------------------------------------
+-- running sessions started with `sisu session start`; one per task
+CREATE TABLE IF NOT EXISTS timers (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	task integer NOT NULL UNIQUE,
+	class text,
+	started datetime NOT NULL,
+	paused datetime,
+	paused_secs integer NOT NULL DEFAULT 0,
+	FOREIGN KEY (task) REFERENCES tasks (id)
+);
+
+----------------------------------------------------------------------------------------------------
@@ -0,0 +1,4 @@
+----------------------------------------------------------------------------------------------------
+ALTER TABLE calendar DROP COLUMN kind;
+
+----------------------------------------------------------------------------------------------------
@@ -0,0 +1,6 @@
+----------------------------------------------------------------------------------------------------
+-- calendar entries are plain notes unless marked as a break or holiday;
+-- break and holiday days are excused from streaks
+ALTER TABLE calendar ADD COLUMN kind text NOT NULL DEFAULT 'note';
+
+----------------------------------------------------------------------------------------------------
@@ -0,0 +1,13 @@
+----------------------------------------------------------------------------------------------------
+UPDATE coach SET trigger = 'forgotten' WHERE trigger = 'days_since_last_session >= 3';
+UPDATE coach SET trigger = 'low performance' WHERE trigger = 'avg_feedback_7d < 2.5';
+UPDATE coach SET trigger = 'superb' WHERE trigger = 'streak >= 7';
+
+UPDATE coach SET content = 'forgotten'
+	WHERE content = 'It has been a few days. A short session keeps the habit alive.';
+UPDATE coach SET content = 'low performance'
+	WHERE content = 'Feedback has been low this week. Ease off or change the approach.';
+UPDATE coach SET content = 'superb'
+	WHERE content = 'A week-long streak. Keep it going!';
+
+----------------------------------------------------------------------------------------------------
@@ -0,0 +1,15 @@
+----------------------------------------------------------------------------------------------------
+-- coach triggers are rules evaluated by `sisu coach check`;
+-- rewrite the free-text triggers seeded by the default profile
+UPDATE coach SET content = 'It has been a few days. A short session keeps the habit alive.'
+	WHERE trigger = 'forgotten' AND content = 'forgotten';
+UPDATE coach SET content = 'Feedback has been low this week. Ease off or change the approach.'
+	WHERE trigger = 'low performance' AND content = 'low performance';
+UPDATE coach SET content = 'A week-long streak. Keep it going!'
+	WHERE trigger = 'superb' AND content = 'superb';
+
+UPDATE coach SET trigger = 'days_since_last_session >= 3' WHERE trigger = 'forgotten';
+UPDATE coach SET trigger = 'avg_feedback_7d < 2.5' WHERE trigger = 'low performance';
+UPDATE coach SET trigger = 'streak >= 7' WHERE trigger = 'superb';
+
+----------------------------------------------------------------------------------------------------
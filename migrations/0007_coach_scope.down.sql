@@ -0,0 +1,17 @@
+----------------------------------------------------------------------------------------------------
+DROP INDEX IF EXISTS coach_deliveries_task_coach;
+DROP TABLE IF EXISTS coach_deliveries;
+
+----------------------------------------------------------------------------------------------------
+-- SQLite cannot drop a column used by a foreign key, so rebuild coach
+CREATE TABLE coach_old (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	trigger text NOT NULL,
+	content text NOT NULL,
+	date date
+);
+INSERT INTO coach_old (id, trigger, content, date) SELECT id, trigger, content, date FROM coach;
+DROP TABLE coach;
+ALTER TABLE coach_old RENAME TO coach;
+
+----------------------------------------------------------------------------------------------------
@@ -0,0 +1,21 @@
+----------------------------------------------------------------------------------------------------
+-- coach rules may be scoped to one task (NULL applies to every task)
+-- and rest for `cooldown` days after being delivered to a task (0 = no rest)
+ALTER TABLE coach ADD COLUMN task integer REFERENCES tasks (id);
+ALTER TABLE coach ADD COLUMN cooldown integer NOT NULL DEFAULT 1;
+
+----------------------------------------------------------------------------------------------------
+-- messages shown by `sisu coach check`, used for cooldowns, rotation and history
+CREATE TABLE IF NOT EXISTS coach_deliveries (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	coach integer NOT NULL,
+	task integer NOT NULL,
+	delivered datetime NOT NULL,
+	content text NOT NULL,
+	FOREIGN KEY (coach) REFERENCES coach (id),
+	FOREIGN KEY (task) REFERENCES tasks (id)
+);
+
+CREATE INDEX IF NOT EXISTS coach_deliveries_task_coach ON coach_deliveries (task, coach, delivered);
+
+----------------------------------------------------------------------------------------------------
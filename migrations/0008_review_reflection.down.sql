@@ -0,0 +1,7 @@
+----------------------------------------------------------------------------------------------------
+ALTER TABLE reviews DROP COLUMN reviewed;
+ALTER TABLE reviews DROP COLUMN commitment;
+ALTER TABLE reviews DROP COLUMN blockers;
+ALTER TABLE reviews DROP COLUMN went_well;
+
+----------------------------------------------------------------------------------------------------
@@ -0,0 +1,19 @@
+----------------------------------------------------------------------------------------------------
+-- structured answers written by `sisu review start`; reviewed is set once a review is done
+ALTER TABLE reviews ADD COLUMN went_well text;
+ALTER TABLE reviews ADD COLUMN blockers text;
+ALTER TABLE reviews ADD COLUMN commitment text;
+ALTER TABLE reviews ADD COLUMN reviewed date;
+
+-- reviews written before this, for weeks already over, count as done on the last day
+-- of their week, so upgrading does not leave every old review pending
+UPDATE reviews SET reviewed = (
+  SELECT date(tasks.start, '+' || (reviews.week * 7 - 1) || ' days') FROM tasks WHERE tasks.id = reviews.task
+)
+WHERE coalesce(summary, '') != '' AND week IS NOT NULL AND EXISTS (
+  SELECT 1 FROM tasks
+  WHERE tasks.id = reviews.task AND tasks.start IS NOT NULL
+    AND date(tasks.start, '+' || (reviews.week * 7) || ' days') <= date('now')
+);
+
+----------------------------------------------------------------------------------------------------
@@ -0,0 +1,12 @@
+----------------------------------------------------------------------------------------------------
+-- done goes back to holding the planned date, or the reached one when none was planned
+UPDATE milestones SET done = coalesce(due, done);
+
+UPDATE milestones SET value = 2 WHERE type = 'courage' AND kind = 'days' AND value = 10;
+UPDATE milestones SET value = 3 WHERE type = 'determination' AND kind = 'minutes' AND value = 1500;
+UPDATE milestones SET value = 4 WHERE type = 'perseverance' AND kind = 'streak' AND value = 21;
+
+ALTER TABLE milestones DROP COLUMN due;
+ALTER TABLE milestones DROP COLUMN kind;
+
+----------------------------------------------------------------------------------------------------
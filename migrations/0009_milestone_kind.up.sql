@@ -0,0 +1,21 @@
+----------------------------------------------------------------------------------------------------
+-- milestones are checked against the session history by kind (minutes, sessions, streak,
+-- feedback, days); manual ones are only completed by hand
+-- done now holds the date a milestone was reached; every date it held so far was the
+-- planned one written by profile seeding, so it moves to due
+ALTER TABLE milestones ADD COLUMN kind text NOT NULL DEFAULT 'manual';
+ALTER TABLE milestones ADD COLUMN due date;
+
+UPDATE milestones SET due = done, done = NULL WHERE done IS NOT NULL;
+
+-- rows the default profile seeded take the kind and value it gives them now,
+-- so `milestone check` can stamp them from the session history
+UPDATE milestones SET kind = 'days', value = 10 WHERE type = 'courage' AND value = 2;
+UPDATE milestones SET kind = 'minutes', value = 1500 WHERE type = 'determination' AND value = 3;
+UPDATE milestones SET kind = 'streak', value = 21 WHERE type = 'perseverance' AND value = 4;
+
+-- other seeded rows have nothing to be checked against; those already past their
+-- planned date count as done on it, as they read before
+UPDATE milestones SET done = due WHERE kind = 'manual' AND date(due) <= date('now');
+
+----------------------------------------------------------------------------------------------------
@@ -0,0 +1,4 @@
+----------------------------------------------------------------------------------------------------
+ALTER TABLE tasks DROP COLUMN goal;
+
+----------------------------------------------------------------------------------------------------
@@ -0,0 +1,5 @@
+----------------------------------------------------------------------------------------------------
+-- minutes a task should reach by its target date; read by `sisu forecast`
+ALTER TABLE tasks ADD COLUMN goal integer;
+
+----------------------------------------------------------------------------------------------------
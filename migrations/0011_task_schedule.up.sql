@@ -0,0 +1,5 @@
+----------------------------------------------------------------------------------------------------
+-- how often a task calls for a session: daily, mon,wed,fri, 3/week or every 2 days; NULL is daily
+ALTER TABLE tasks ADD COLUMN schedule text;
+
+----------------------------------------------------------------------------------------------------
@@ -0,0 +1,31 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package migrations
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import "embed"
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// FS holds every golang-migrate file so the binary carries its own schema
+// and can migrate a database from any working directory.
+//
+//go:embed *.sql
+var FS embed.FS
+
+////////////////////////////////////////////////////////////////////////////////////////////////////